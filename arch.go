@@ -0,0 +1,113 @@
+package user_agent
+
+import "strings"
+
+// archTokens maps the User-Agent tokens indicating a CPU architecture (in lower case) to the Architecture. Note that
+// WOW64 indicates a 32-bit application running on 64-bit Windows, so the device architecture is x86_64.
+var archTokens = map[string]Architecture{
+	"x86_64":  ArchitectureX86_64,
+	"x64":     ArchitectureX86_64,
+	"win64":   ArchitectureX86_64,
+	"wow64":   ArchitectureX86_64,
+	"amd64":   ArchitectureX86_64,
+	"i386":    ArchitectureX86,
+	"i486":    ArchitectureX86,
+	"i586":    ArchitectureX86,
+	"i686":    ArchitectureX86,
+	"aarch64": ArchitectureARM64,
+	"arm64":   ArchitectureARM64,
+	"arm_64":  ArchitectureARM64, // e.g. Yandex Browser on Android
+	"armv7l":  ArchitectureARM,
+	"armv8l":  ArchitectureARM, // 32-bit userland on a 64-bit CPU
+	"arm":     ArchitectureARM,
+}
+
+// setArchitecture sets the CPU architecture and bitness indicated by the User-Agent string, leaving them empty if
+// they're unknown. The tokens are found in the comments (e.g. "Windows NT 10.0; Win64; x64" or "X11; Linux x86_64"),
+// which the Fields omit. Apple's iOS, iPadOS, and tvOS devices all have 64-bit ARM processors. However, a Mac reports
+// "Intel Mac OS X" even when it has an Apple Silicon processor, so its architecture is only available from the Client
+// Hints.
+func (ua *UserAgent) setArchitecture() {
+	ua.Architecture = ""
+	if ua.OSName == OSNameIOS || ua.OSName == OSNameIPadOS || ua.OSName == OSNameTvOS {
+		ua.Architecture = ArchitectureARM64
+	} else if ua.OSName != OSNameMacOS {
+		ua.Architecture = commentArchitecture(ua.Products)
+	}
+	ua.Bitness = ua.Architecture.Bitness()
+}
+
+// commentArchitecture returns the first CPU architecture indicated by a token in the User-Agent comments, if any.
+func commentArchitecture(products []Product) Architecture {
+	for _, p := range products {
+		for _, c := range p.Comments {
+			for i := 0; i < len(c); {
+				start := i
+				for i < len(c) && !isArchSeparator(rune(c[i])) {
+					i++
+				}
+				if a, ok := archToken(c[start:i]); ok {
+					return a
+				}
+				i++
+			}
+		}
+	}
+	return ""
+}
+
+// archToken returns the CPU architecture indicated by the supplied comment token, ignoring case.
+func archToken(token string) (Architecture, bool) {
+	var lower [8]byte // the longest architecture token is aarch64
+	if token == "" || len(token) > len(lower) {
+		return "", false
+	}
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		lower[i] = c
+	}
+	a, ok := archTokens[string(lower[:len(token)])] // the conversion doesn't allocate
+	return a, ok
+}
+
+// isArchSeparator returns true for the characters separating tokens in a User-Agent comment.
+func isArchSeparator(r rune) bool {
+	return r == ' ' || r == ';' || r == ',' || r == '(' || r == ')'
+}
+
+// applyArchitectureHints sets the CPU architecture and bitness indicated by the Sec-CH-UA-Arch and
+// Sec-CH-UA-Bitness headers (e.g. "x86" and "64"), which take precedence over the User-Agent string.
+// Apple Silicon Macs report "arm", and they're all 64-bit.
+func (ua *UserAgent) applyArchitectureHints(arch, bitness string) {
+	bits := 0
+	switch bitness {
+	case "32":
+		bits = 32
+	case "64":
+		bits = 64
+	}
+	if bits == 0 && strings.EqualFold(arch, "arm") && ua.OSName == OSNameMacOS {
+		bits = 64
+	}
+	if bits == 0 {
+		bits = ua.Bitness
+	}
+	switch strings.ToLower(arch) {
+	case "x86":
+		ua.Architecture = ArchitectureX86
+		if bits == 64 {
+			ua.Architecture = ArchitectureX86_64
+		}
+	case "arm":
+		ua.Architecture = ArchitectureARM
+		if bits == 64 {
+			ua.Architecture = ArchitectureARM64
+		}
+	}
+	if bits != 0 {
+		ua.Bitness = bits
+	}
+}
@@ -0,0 +1,127 @@
+package user_agent
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestArchitectures(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		arch     Architecture
+		bitness  int
+		archHint string
+		bitsHint string
+	}{
+		{
+			name:    "Win64",
+			ua:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36",
+			arch:    ArchitectureX86_64,
+			bitness: 64,
+		},
+		{
+			name:    "WOW64",
+			ua:      "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			arch:    ArchitectureX86_64,
+			bitness: 64,
+		},
+		{
+			name:    "Windows32",
+			ua:      "Mozilla/5.0 (Windows NT 10.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36",
+			arch:    "",
+			bitness: 0,
+		},
+		{
+			name:    "Linux",
+			ua:      "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36",
+			arch:    ArchitectureX86_64,
+			bitness: 64,
+		},
+		{
+			name:    "i686",
+			ua:      "Mozilla/5.0 (X11; Ubuntu; Linux i686; rv:101.0) Gecko/20100101 Firefox/101.0",
+			arch:    ArchitectureX86,
+			bitness: 32,
+		},
+		{
+			name:    "ChromeOS",
+			ua:      "Mozilla/5.0 (X11; CrOS aarch64 14695.25.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/102.0.0.0 Safari/537.36",
+			arch:    ArchitectureARM64,
+			bitness: 64,
+		},
+		{
+			name:    "Yandex",
+			ua:      "Mozilla/5.0 (Linux; arm_64; Android 12; SM-G965F) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/112.0.0.0 YaBrowser/23.3.3.86.00 SA/3 Mobile Safari/537.36",
+			arch:    ArchitectureARM64,
+			bitness: 64,
+		},
+		{
+			name:    "RaspberryPi",
+			ua:      "Mozilla/5.0 (X11; Linux armv7l) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36",
+			arch:    ArchitectureARM,
+			bitness: 32,
+		},
+		{
+			name:    "iPhone",
+			ua:      "Mozilla/5.0 (iPhone; CPU iPhone OS 15_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1",
+			arch:    ArchitectureARM64,
+			bitness: 64,
+		},
+		{
+			name:    "Mac",
+			ua:      "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.4 Safari/605.1.15",
+			arch:    "",
+			bitness: 0,
+		},
+		{
+			name:     "AppleSilicon",
+			ua:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36",
+			arch:     ArchitectureARM64,
+			bitness:  64,
+			archHint: `"arm"`,
+		},
+		{
+			name:     "IntelMac",
+			ua:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36",
+			arch:     ArchitectureX86_64,
+			bitness:  64,
+			archHint: `"x86"`,
+			bitsHint: `"64"`,
+		},
+		{
+			name:     "WindowsARM",
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36",
+			arch:     ArchitectureARM64,
+			bitness:  64,
+			archHint: `"arm"`,
+			bitsHint: `"64"`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("User-Agent", c.ua)
+			if c.archHint != "" {
+				h.Set("Sec-CH-UA-Arch", c.archHint)
+			}
+			if c.bitsHint != "" {
+				h.Set("Sec-CH-UA-Bitness", c.bitsHint)
+			}
+			ua := ParseHeaders(h)
+			if ua.Architecture != c.arch || ua.Bitness != c.bitness {
+				t.Errorf("expected/received:\n%s %d\n%s %d", c.arch, c.bitness, ua.Architecture, ua.Bitness)
+			}
+		})
+	}
+}
+
+// TestArchitectures_fields checks that the architecture tokens are still omitted from the Fields.
+func TestArchitectures_fields(t *testing.T) {
+	ua := Parse("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36")
+	for _, f := range ua.Fields {
+		if f == "Win64" || f == "x64" {
+			t.Errorf("unexpected field %q", f)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package user_agent
+
+import "strings"
+
+// automationTokens lists the User-Agent product names that indicate a browser automation framework, in order of
+// precedence. Selenium, Playwright, and Puppeteer only identify themselves when configured to do so, and otherwise
+// drive a headless browser (e.g. HeadlessChrome). Cypress runs its tests in Electron, so it must precede Electron.
+var automationTokens = []string{"Selenium", "Playwright", "Puppeteer", "Cypress", "PhantomJS", "HeadlessChrome", "Electron"}
+
+// setAutomation sets the name of the browser automation framework indicated by the User-Agent string, if any.
+// Automated clients are bots, but the ClientName and ClientVersion still report the underlying browser (e.g. Chrome
+// for HeadlessChrome), unless a pattern matcher identified the bot itself (e.g. Lighthouse or Pa11y). An identified
+// bot without a version of its own has no ClientVersion, rather than the version of the browser it drives. Electron
+// also hosts desktop applications (e.g. Slack and Visual Studio Code), so it's reported without changing the client
+// type.
+func (ua *UserAgent) setAutomation() {
+	ua.Automation = automationName(ua.Products)
+	if ua.Automation == "" || ua.Automation == "Electron" {
+		return
+	}
+	if ua.ClientType != ClientTypeBot && ua.ClientType != ClientTypeLibrary {
+		ua.ClientType = ClientTypeBot
+	}
+}
+
+// automationName returns the name of the first automation framework found in the User-Agent products, if any.
+func automationName(products []Product) string {
+	for _, token := range automationTokens {
+		for _, p := range products {
+			if strings.EqualFold(p.Name, token) {
+				return token
+			}
+		}
+	}
+	return ""
+}
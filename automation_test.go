@@ -0,0 +1,29 @@
+package user_agent
+
+import "testing"
+
+func TestAutomation(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		expected string
+	}{
+		{"HeadlessChrome", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/101.0.4950.0 Safari/537.36", "Bot Chrome 101.0 HeadlessChrome Automation"},
+		{"PhantomJS", "Mozilla/5.0 (Unknown; Linux x86_64) AppleWebKit/538.1 (KHTML, like Gecko) PhantomJS/2.1.1 Safari/538.1", "Bot Safari  PhantomJS Automation"},
+		{"Cypress", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Cypress/12.3.0 Chrome/106.0.5249.51 Electron/21.0.0 Safari/537.36", "Bot Chrome 106.0 Cypress Automation"},
+		{"Selenium", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36 Selenium/4.8.0", "Bot Chrome 110.0 Selenium Automation"},
+		{"Puppeteer", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/112.0.5614.0 Safari/537.36 Puppeteer/19.8.0", "Bot Chrome 112.0 Puppeteer Automation"},
+		{"Lighthouse", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/109.0.5414.119 Safari/537.36 Chrome-Lighthouse", "Bot Lighthouse  HeadlessChrome Monitoring"},
+		{"Electron", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Slack/4.29.149 Chrome/106.0.5249.168 Electron/21.3.3 Safari/537.36 Sonic Slack_SSB/4.29.149", "Browser Chrome 106.0 Electron "},
+		{"Browser", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36", "Browser Chrome 101.0  "},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			received := string(ua.ClientType) + " " + ua.ClientName + " " + ua.ClientVersion + " " + ua.Automation + " " + string(ua.BotCategory)
+			if received != c.expected {
+				t.Errorf("expected/received:\n%s\n%s", c.expected, received)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package user_agent
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+)
+
+// botData contains the built-in bot registry. Each entry is a JSON object with the fields of a BotInfo, keyed by the
+// ClientName provided by the pattern matchers.
+//
+//go:embed bots.json
+var botData []byte
+
+// BotInfo provides information about a bot, from the built-in bot registry.
+type BotInfo struct {
+	// Name is the bot's ClientName (Googlebot, AhrefsBot, etc.)
+	Name string `json:"name"`
+
+	// Category indicates the purpose of the bot (Search, SEO, Preview, AI, etc.)
+	Category BotCategory `json:"category"`
+
+	// Operator indicates the company or project operating the bot, if known
+	Operator string `json:"operator,omitempty"`
+
+	// URL provides the operator's documentation for the bot, if available
+	URL string `json:"url,omitempty"`
+
+	// RobotsTxt indicates whether the bot is known to honor robots.txt directives
+	RobotsTxt bool `json:"robotsTxt"`
+
+	// Note is an optional comment about the bot (e.g. that its name is only a robots.txt token)
+	Note string `json:"note,omitempty"`
+}
+
+// bots contains the built-in bot registry, keyed by name.
+var bots = mustLoadBots(botData)
+
+// mustLoadBots loads the built-in bot registry, panicking if it's malformed.
+func mustLoadBots(data []byte) map[string]BotInfo {
+	var entries []BotInfo
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&entries); err != nil {
+		panic("user_agent: built-in bots.json: " + err.Error())
+	}
+	registry := make(map[string]BotInfo, len(entries))
+	for _, b := range entries {
+		if b.Name == "" || !b.Category.IsValid() {
+			panic("user_agent: built-in bots.json: invalid entry " + b.Name)
+		}
+		registry[b.Name] = b
+	}
+	return registry
+}
+
+// LookupBot returns information about the bot with the supplied ClientName (e.g. Googlebot), and whether it was found
+// in the built-in bot registry.
+func LookupBot(name string) (BotInfo, bool) {
+	b, ok := bots[name]
+	return b, ok
+}
+
+// setBotCategory sets the bot category from the bot registry, if the client is a bot. Bots that aren't in the
+// registry are in the Automation category if they're automated browsers (e.g. HeadlessChrome), or otherwise in the
+// Other category (e.g. those identified only by a URL). Scanners are flagged as suspicious.
+func (ua *UserAgent) setBotCategory() {
+	ua.BotCategory = ""
+	ua.Suspicious = false
+	if ua.ClientType != ClientTypeBot {
+		return
+	}
+	ua.BotCategory = BotCategoryOther
+	if b, ok := bots[ua.ClientName]; ok {
+		ua.BotCategory = b.Category
+	} else if ua.Automation != "" {
+		ua.BotCategory = BotCategoryAutomation
+	}
+	ua.Suspicious = ua.BotCategory == BotCategoryScanner
+}
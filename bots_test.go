@@ -0,0 +1,78 @@
+package user_agent
+
+import "testing"
+
+// TestLookupBot checks that every bot provided by the built-in pattern matchers is in the bot registry.
+func TestLookupBot(t *testing.T) {
+	for _, m := range patterns {
+		if m.ClientType != ClientTypeBot {
+			continue
+		}
+		b, ok := LookupBot(m.ClientName)
+		if !ok {
+			t.Errorf("bot %q is missing from bots.json", m.ClientName)
+			continue
+		}
+		if b.Name != m.ClientName || !b.Category.IsValid() {
+			t.Errorf("bot %q has an invalid entry: %+v", m.ClientName, b)
+		}
+	}
+	// robots.txt tokens that never appear in a User-Agent string are still registered
+	for _, name := range []string{"Google-Extended", "Applebot-Extended"} {
+		if b, ok := LookupBot(name); !ok || b.Category != BotCategoryAI || b.Note == "" {
+			t.Errorf("robots.txt token %q has an invalid entry: %+v", name, b)
+		}
+	}
+	if _, ok := LookupBot("Chrome"); ok {
+		t.Error("expected Chrome to be missing from the bot registry")
+	}
+}
+
+func TestBotCategories(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		expected BotCategory
+	}{
+		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", BotCategorySearch},
+		{"AhrefsBot", "Mozilla/5.0 (compatible; AhrefsBot/7.0; +http://ahrefs.com/robot/)", BotCategorySEO},
+		{"AdsBot", "AdsBot-Google (+http://www.google.com/adsbot.html)", BotCategoryAdvertising},
+		{"FacebookBot", "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", BotCategoryPreview},
+		{"Pa11y", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/90.0.4421.0 Safari/537.36 pa11y/6.1.1", BotCategoryAccessibility},
+		{"HeadlessChrome", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/101.0.4950.0 Safari/537.36", BotCategoryAutomation},
+		{"Lighthouse", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/109.0.5414.119 Safari/537.36 Chrome-Lighthouse", BotCategoryMonitoring},
+		{"Scanner", "sqlmap/1.7.2#stable (https://sqlmap.org)", BotCategoryScanner},
+		{"URL", "Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X Build/MMB29P) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/41.0.2272.96 Mobile Safari/537.36 +https://sitebulb.com", BotCategoryOther},
+		{"Browser", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			if ua.BotCategory != c.expected {
+				t.Errorf("expected/received:\n%q\n%q", c.expected, ua.BotCategory)
+			}
+		})
+	}
+}
+
+func TestSuspicious(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		expected bool
+	}{
+		{"Nikto", "Mozilla/5.00 (Nikto/2.1.6) (Evasions:None) (Test:Port Check)", true},
+		{"Nmap", "Mozilla/5.0 (compatible; Nmap Scripting Engine; https://nmap.org/book/nse.html)", true},
+		{"Acunetix", "Mozilla/5.0 (compatible; MSIE 9.0; Windows NT 6.1; Trident/5.0; Acunetix Web Vulnerability Scanner)", true},
+		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", false},
+		{"Browser", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			if ua.Suspicious != c.expected {
+				t.Errorf("expected/received:\n%t\n%t", c.expected, ua.Suspicious)
+			}
+		})
+	}
+}
@@ -57,14 +57,14 @@ func main() {
 		for s, c := range uac.StringCounts {
 			uaCount += c
 			userAgent := user_agent.Parse(s)
-			ctCount := clientTypeCounts[userAgent.ClientType]
-			clientTypeCounts[userAgent.ClientType] = ctCount + c
+			ctCount := clientTypeCounts[string(userAgent.ClientType)]
+			clientTypeCounts[string(userAgent.ClientType)] = ctCount + c
 			cnCount := clientNameCounts[userAgent.ClientName]
 			clientNameCounts[userAgent.ClientName] = cnCount + c
-			dtCount := deviceTypeCounts[userAgent.DeviceType]
-			deviceTypeCounts[userAgent.DeviceType] = dtCount + c
-			osCount := osNameCounts[userAgent.OSName]
-			osNameCounts[userAgent.OSName] = osCount + c
+			dtCount := deviceTypeCounts[string(userAgent.DeviceType)]
+			deviceTypeCounts[string(userAgent.DeviceType)] = dtCount + c
+			osCount := osNameCounts[string(userAgent.OSName)]
+			osNameCounts[string(userAgent.OSName)] = osCount + c
 			if userAgent.URL != "" {
 				urlCount := urlCounts[userAgent.URL]
 				urlCounts[userAgent.URL] = urlCount + c
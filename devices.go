@@ -0,0 +1,190 @@
+package user_agent
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+)
+
+// deviceData contains the built-in device table. Each entry is a JSON object with either a "model" (an exact device
+// model identifier, with its vendor and marketing name) or a "prefix" (a model identifier prefix used by a vendor).
+//
+//go:embed devices.json
+var deviceData []byte
+
+// device is an entry in the device table.
+type device struct {
+	Model  string `json:"model"`
+	Prefix string `json:"prefix"`
+	Vendor string `json:"vendor"`
+	Name   string `json:"name"`
+}
+
+// deviceTable is used to look up device vendors and marketing names by model identifier.
+type deviceTable struct {
+	models   map[string]device // exact model identifiers
+	prefixes []device          // model identifier prefixes, in order, with lower-case Prefix text
+	vendors  map[string]string // lower-case vendor names to their canonical spelling
+}
+
+// devices contains the built-in device table.
+var devices = mustLoadDevices(deviceData)
+
+// mustLoadDevices loads the built-in device table, panicking if it's malformed.
+func mustLoadDevices(data []byte) *deviceTable {
+	var entries []device
+	if err := json.Unmarshal(data, &entries); err != nil {
+		panic("user_agent: built-in devices.json: " + err.Error())
+	}
+	t := &deviceTable{models: make(map[string]device), vendors: make(map[string]string)}
+	for _, d := range entries {
+		if d.Model != "" {
+			t.models[d.Model] = d
+		} else if d.Prefix != "" {
+			d.Prefix = strings.ToLower(d.Prefix)
+			t.prefixes = append(t.prefixes, d)
+		}
+		t.vendors[strings.ToLower(d.Vendor)] = d.Vendor
+	}
+	return t
+}
+
+// lookup returns the vendor and marketing name of the device with the supplied model identifier. The marketing name
+// is only known for models in the table, but the vendor may be identified by the model's prefix (e.g. SM- is used by
+// Samsung).
+func (t *deviceTable) lookup(model string) (vendor, name string) {
+	if d, ok := t.models[model]; ok {
+		return d.Vendor, d.Name
+	}
+	lower := strings.ToLower(model)
+	for _, d := range t.prefixes {
+		if strings.HasPrefix(lower, d.Prefix) {
+			return d.Vendor, ""
+		}
+	}
+	return "", ""
+}
+
+// vendor returns the canonical spelling of the supplied vendor name (e.g. samsung is Samsung), if it's known.
+func (t *deviceTable) vendor(name string) string {
+	if v, ok := t.vendors[strings.ToLower(name)]; ok {
+		return v
+	}
+	return name
+}
+
+// setDevice sets the device model, vendor, and marketing name. In-app browser metadata (e.g. from the Facebook and
+// Instagram apps) is preferred, because it often identifies the exact model of Apple devices, and then the comment
+// following the Android version (e.g. "Linux; Android 12; SM-G991B Build/SP1A.210812.016"). Amazon Fire TV models
+// start with AFT (e.g. AFTMM), whereas Fire tablets are KF models, so an AFT model indicates a TV.
+func (ua *UserAgent) setDevice() {
+	model, vendor := appDevice(ua.Header, ua.Products)
+	if model == "" && ua.OSName == OSNameAndroid {
+		model = androidModel(ua.Products)
+	}
+	ua.setDeviceModel(model, vendor)
+	if strings.HasPrefix(model, "AFT") && ua.DeviceVendor == "Amazon" {
+		ua.DeviceType = DeviceTypeTV
+	}
+}
+
+// setDeviceModel sets the device model, looking up its vendor and marketing name. The supplied vendor is used if the
+// model's vendor isn't known.
+func (ua *UserAgent) setDeviceModel(model, vendor string) {
+	ua.DeviceModel = model
+	ua.DeviceVendor, ua.DeviceName = devices.lookup(model)
+	if ua.DeviceVendor == "" && vendor != "" {
+		ua.DeviceVendor = devices.vendor(vendor)
+	}
+}
+
+// appDevice returns the device model and vendor provided by an in-app browser, if any. The Facebook apps provide
+// them in bracketed metadata (e.g. [FBAN/FBIOS;FBDV/iPhone14,2;FBMD/iPhone;...]), and the Instagram app provides
+// them in the comment following its version (e.g. "Instagram 233.0.0.13.112 Android (31/12; 420dpi; 1080x2182;
+// samsung; SM-N981U1; ...)" or "Instagram 221.0.0.9.115 (iPhone13,2; iOS 14_3; ...)").
+func appDevice(header string, products []Product) (model, vendor string) {
+	if i := strings.Index(header, "FBDV/"); i >= 0 {
+		model = fbValue(header[i+5:])
+		if j := strings.Index(header, "FBMD/"); j >= 0 {
+			vendor = fbValue(header[j+5:])
+		}
+		if model != "" {
+			if vendor == model || strings.HasPrefix(model, vendor) {
+				vendor = "" // e.g. FBDV/iPhone12,5;FBMD/iPhone
+			}
+			return model, vendor
+		}
+	}
+	for i, p := range products {
+		if p.Name != "Instagram" {
+			continue
+		}
+		for _, q := range products[i:] {
+			if len(q.Comments) == 0 {
+				continue
+			}
+			segments := strings.Split(q.Comments[0], ";")
+			for s := range segments {
+				segments[s] = strings.TrimSpace(segments[s])
+			}
+			if len(segments) >= 5 && strings.HasSuffix(segments[1], "dpi") {
+				vendor, _, _ = strings.Cut(segments[3], "/") // e.g. Xiaomi/Redmi
+				return segments[4], vendor
+			}
+			return segments[0], ""
+		}
+	}
+	return "", ""
+}
+
+// fbValue returns the value of a Facebook app metadata field, which is terminated by a semicolon or bracket.
+func fbValue(s string) string {
+	if i := strings.IndexAny(s, ";]"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+// androidModel returns the device model following the Android version in a User-Agent comment, if provided.
+// Other segments (e.g. the locale, "wv" for a WebView, or "Mobile") and any build details are skipped.
+func androidModel(products []Product) string {
+	for _, p := range products {
+		for _, c := range p.Comments {
+			android := false // a segment with the Android version precedes the current segment
+			for s, rest, more := "", c, true; more; {
+				s, rest, more = strings.Cut(rest, ";")
+				s = strings.TrimSpace(s)
+				if !android {
+					android = strings.HasPrefix(s, "Android")
+					continue
+				}
+				s, _, _ = strings.Cut(s, "Build/")
+				s = strings.TrimSpace(s)
+				if !isAndroidNoise(s) {
+					return s
+				}
+			}
+			if android {
+				return ""
+			}
+		}
+	}
+	return ""
+}
+
+// isAndroidNoise returns true if the supplied Android comment segment doesn't identify a device model.
+// Note that "K" is the placeholder model provided by Chrome's reduced User-Agent string.
+func isAndroidNoise(s string) bool {
+	switch s {
+	case "", "U", "K", "wv", "Mobile", "Tablet", "Linux", "HarmonyOS":
+		return true
+	}
+	if strings.HasPrefix(s, "rv:") {
+		return true
+	}
+	// locale (e.g. en, en-us, or en_US)
+	if len(s) == 2 || (len(s) == 5 && (s[2] == '-' || s[2] == '_')) {
+		return strings.ToLower(s[:2]) == s[:2]
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package user_agent
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDevices(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		expected [3]string // vendor, model, name
+	}{
+		{
+			name:     "Samsung",
+			ua:       "Mozilla/5.0 (Linux; Android 12; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.61 Mobile Safari/537.36",
+			expected: [3]string{"Samsung", "SM-G991B", "Galaxy S21"},
+		},
+		{
+			name:     "Build",
+			ua:       "Mozilla/5.0 (Linux; Android 8.1.0; Nexus 5X Build/OPM7.181205.001) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/99.0.4844.88 Mobile Safari/537.36",
+			expected: [3]string{"Google", "Nexus 5X", "Nexus 5X"},
+		},
+		{
+			name:     "Locale",
+			ua:       "Mozilla/5.0 (Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K) AppleWebkit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30",
+			expected: [3]string{"LG", "LG-L160L", ""},
+		},
+		{
+			name:     "Nested",
+			ua:       "Mozilla/5.0 (Linux; Android 12; Pixel 4a (5G)) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.61 Mobile Safari/537.36",
+			expected: [3]string{"Google", "Pixel 4a (5G)", "Pixel 4a (5G)"},
+		},
+		{
+			name:     "WebView",
+			ua:       "Mozilla/5.0 (Linux; Android 11; moto g power (2021) Build/RZBS31.Q2-143-27-25; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/100.0.4896.127 Mobile Safari/537.36",
+			expected: [3]string{"Motorola", "moto g power (2021)", ""},
+		},
+		{
+			name:     "Reduced",
+			ua:       "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36",
+			expected: [3]string{"", "", ""},
+		},
+		{
+			name:     "Firefox",
+			ua:       "Mozilla/5.0 (Android 12; Mobile; rv:101.0) Gecko/101.0 Firefox/101.0",
+			expected: [3]string{"", "", ""},
+		},
+		{
+			name:     "Facebook",
+			ua:       "Mozilla/5.0 (iPhone; CPU iPhone OS 14_7_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 LightSpeed [FBAN/MessengerLiteForiOS;FBAV/358.0.0.12.127;FBBV/365620646;FBDV/iPhone13,4;FBMD/iPhone;FBSN/iOS;FBSV/14.7.1;FBSS/3;FBCR/;FBID/phone;FBLC/en-GB;FBOP/0]",
+			expected: [3]string{"Apple", "iPhone13,4", "iPhone 12 Pro Max"},
+		},
+		{
+			name:     "InstagramAndroid",
+			ua:       "Mozilla/5.0 (Linux; Android 11; M2003J15SC Build/RP1A.200720.011; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/98.0.4758.101 Mobile Safari/537.36 Instagram 223.1.0.14.103 Android (30/11; 440dpi; 1080x2110; Xiaomi/Redmi; M2003J15SC; merlinnfc; mt6768; it_IT; 352895594)",
+			expected: [3]string{"Xiaomi", "M2003J15SC", "Redmi Note 9"},
+		},
+		{
+			name:     "InstagramVendor",
+			ua:       "Mozilla/5.0 (Linux; Android 12; AB-1234 Build/SP1A.210812.016; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/100.0.4896.127 Mobile Safari/537.36 Instagram 233.0.0.13.112 Android (31/12; 420dpi; 1080x2182; samsung; AB-1234; c1q; qcom; en_US; 367202479)",
+			expected: [3]string{"Samsung", "AB-1234", ""},
+		},
+		{
+			name:     "InstagramIOS",
+			ua:       "Mozilla/5.0 (iPhone; CPU iPhone OS 14_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 221.0.0.9.115 (iPhone13,2; iOS 14_3; en_ZA; en-GB; scale=3.00; 1170x2532; 349031919)",
+			expected: [3]string{"Apple", "iPhone13,2", "iPhone 12"},
+		},
+		{
+			name:     "Windows",
+			ua:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36",
+			expected: [3]string{"", "", ""},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			received := [3]string{ua.DeviceVendor, ua.DeviceModel, ua.DeviceName}
+			if received != c.expected {
+				t.Errorf("expected/received:\n%q\n%q", c.expected, received)
+			}
+		})
+	}
+}
+
+func TestDevices_hints(t *testing.T) {
+	h := http.Header{}
+	h.Set("User-Agent", "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36")
+	h.Set("Sec-CH-UA-Model", `"Pixel 7"`)
+	ua := ParseHeaders(h)
+	if ua.DeviceVendor != "Google" || ua.DeviceModel != "Pixel 7" || ua.DeviceName != "Pixel 7" {
+		t.Errorf("unexpected device %q %q %q", ua.DeviceVendor, ua.DeviceModel, ua.DeviceName)
+	}
+}
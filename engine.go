@@ -0,0 +1,103 @@
+package user_agent
+
+import "strings"
+
+// setEngine sets the browser rendering engine and its major.minor version, using the products in the User-Agent
+// string, in order of precedence:
+//
+//   - Every browser on iOS and iPadOS (including CriOS, FxiOS, and EdgiOS) must use WebKit.
+//   - Presto/ indicates Opera 12 and earlier.
+//   - Edge/ indicates legacy Edge (EdgeHTML), whereas Edge 79 and later (Edg/) uses Blink.
+//   - Trident/ or MSIE indicates Internet Explorer.
+//   - Gecko/ indicates Firefox and its derivatives, with the engine version provided by rv: (Gecko/20100101 is a
+//     frozen build date).
+//   - AppleWebKit/ with Chrome/ (or Chromium/) indicates Blink, which is versioned with Chrome. Otherwise, it's
+//     WebKit, versioned by AppleWebKit/.
+func (ua *UserAgent) setEngine() {
+	webKit, isWebKit := productVersion(ua.Products, "AppleWebKit")
+	trident, isTrident := tridentVersion(ua.Products)
+	if ua.OSName == OSNameIOS || ua.OSName == OSNameIPadOS {
+		if isWebKit {
+			ua.setEngineVersion(EngineNameWebKit, webKit)
+		}
+	} else if ver, ok := productVersion(ua.Products, "Presto"); ok {
+		ua.setEngineVersion(EngineNamePresto, ver)
+	} else if ver, ok := productVersion(ua.Products, "Edge"); ok {
+		ua.setEngineVersion(EngineNameEdgeHTML, ver)
+	} else if isTrident {
+		ua.setEngineVersion(EngineNameTrident, trident)
+	} else if ver, ok := productVersion(ua.Products, "Gecko"); ok && !isWebKit {
+		if rv := releaseVersion(ua.Fields); rv != "" || isGeckoDate(ver) {
+			ver = rv
+		}
+		ua.setEngineVersion(EngineNameGecko, ver)
+	} else if ver, ok := productVersion(ua.Products, "Chrome", "Chromium", "HeadlessChrome"); ok && isWebKit {
+		ua.setEngineVersion(EngineNameBlink, ver)
+	} else if isWebKit {
+		ua.setEngineVersion(EngineNameWebKit, webKit)
+	}
+}
+
+// productVersion returns the version of the first product with one of the supplied names, and whether it was found.
+func productVersion(products []Product, names ...string) (string, bool) {
+	for _, p := range products {
+		for _, name := range names {
+			if p.Name == name {
+				return p.Version, true
+			}
+		}
+	}
+	return "", false
+}
+
+// tridentVersion returns the Trident version provided in a User-Agent comment (e.g. "Windows NT 6.1; Trident/7.0"),
+// and whether Trident is indicated, either by the Trident token or an MSIE token, which lacks a Trident version.
+func tridentVersion(products []Product) (string, bool) {
+	found := false
+	for _, p := range products {
+		for _, c := range p.Comments {
+			for s, rest, more := "", c, true; more; {
+				s, rest, more = strings.Cut(rest, ";")
+				s = strings.TrimSpace(s)
+				if strings.HasPrefix(s, "Trident/") {
+					return s[8:], true
+				}
+				found = found || strings.HasPrefix(s, "MSIE ")
+			}
+		}
+	}
+	return "", found
+}
+
+// engineFamilies maps each rendering engine to the browser family that uses it.
+var engineFamilies = map[EngineName]string{
+	EngineNameBlink:    "Chromium",
+	EngineNameWebKit:   "Safari",
+	EngineNameGecko:    "Firefox",
+	EngineNameTrident:  "InternetExplorer",
+	EngineNameEdgeHTML: "Edge",
+	EngineNamePresto:   "Opera",
+}
+
+// setFamily sets the browser family indicated by the rendering engine, if the client is a browser.
+func (ua *UserAgent) setFamily() {
+	ua.ClientFamily = ""
+	if ua.ClientType == ClientTypeBrowser {
+		ua.ClientFamily = engineFamilies[ua.EngineName]
+	}
+}
+
+// setEngineVersion sets the engine name, and the major.minor engine version from the full version text provided.
+func (ua *UserAgent) setEngineVersion(name EngineName, ver string) {
+	ua.EngineName = name
+	if isDigits(ver) {
+		ua.EngineVersion = shortVersion(ver)
+	} else {
+		ua.EngineVersion = ""
+	}
+}
+
+// isGeckoDate returns true if the Gecko product version is a build date (e.g. 20100101), not an engine version.
+func isGeckoDate(ver string) bool {
+	return len(ver) == 8 && isDigits(ver) && !strings.ContainsAny(ver, "._")
+}
@@ -0,0 +1,62 @@
+package user_agent
+
+import "testing"
+
+func TestEngines(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		expected string
+	}{
+		{"Chrome", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36", "Blink 101.0"},
+		{"Edge", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36 Edg/101.0.1210.53", "Blink 101.0"},
+		{"EdgeLegacy", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19582", "EdgeHTML 18.19582"},
+		{"Opera", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/100.0.4896.127 Safari/537.36 OPR/86.0.4363.59", "Blink 100.0"},
+		{"OperaPresto", "Opera/9.80 (Windows NT 6.1; WOW64) Presto/2.12.388 Version/12.18", "Presto 2.12"},
+		{"Firefox", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0", "Gecko 102.0"},
+		{"FirefoxAndroid", "Mozilla/5.0 (Android 12; Mobile; rv:101.0) Gecko/101.0 Firefox/101.0", "Gecko 101.0"},
+		{"InternetExplorer", "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko", "Trident 7.0"},
+		{"MSIE", "Mozilla/4.0 (compatible; MSIE 6.0; Windows NT 5.1)", "Trident "},
+		{"Safari", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.4 Safari/605.1.15", "WebKit 605.1"},
+		{"CriOS", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/102.0.5005.87 Mobile/15E148 Safari/604.1", "WebKit 605.1"},
+		{"FxiOS", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) FxiOS/101.0 Mobile/15E148 Safari/605.1.15", "WebKit 605.1"},
+		{"EdgiOS", "Mozilla/5.0 (iPad; CPU OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) EdgiOS/101.0.1210.53 Version/15.0 Mobile/15E148 Safari/604.1", "WebKit 605.1"},
+		{"WebView", "Mozilla/5.0 (Linux; Android 12; SM-G991B Build/SP1A.210812.016; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/101.0.4951.61 Mobile Safari/537.36", "Blink 101.0"},
+		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", " "},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			received := string(ua.EngineName) + " " + ua.EngineVersion
+			if received != c.expected {
+				t.Errorf("expected/received:\n%s\n%s", c.expected, received)
+			}
+		})
+	}
+}
+
+func TestFamilies(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		expected string
+	}{
+		{"Brave", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Brave Chrome/79.0.3945.88 Safari/537.36", "Brave Chromium"},
+		{"Vivaldi", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/102.0.5005.63 Safari/537.36 Vivaldi/5.3.2679.50", "Vivaldi Chromium"},
+		{"Opera", "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/51.0.2704.106 Safari/537.36 OPR/38.0.2220.41", "Opera Chromium"},
+		{"Waterfox", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:91.0) Gecko/20100101 Firefox/91.0 Waterfox/91.10.0", "Waterfox Firefox"},
+		{"CriOS", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/102.0.5005.87 Mobile/15E148 Safari/604.1", "Chrome Safari"},
+		{"EdgeLegacy", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19582", "Edge Edge"},
+		{"InternetExplorer", "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko", "InternetExplorer InternetExplorer"},
+		{"Instagram", "Mozilla/5.0 (iPhone; CPU iPhone OS 14_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 221.0.0.9.115 (iPhone13,2; iOS 14_3; en_ZA; en-GB; scale=3.00; 1170x2532; 349031919)", "Instagram "},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			received := ua.ClientName + " " + ua.ClientFamily
+			if received != c.expected {
+				t.Errorf("expected/received:\n%s\n%s", c.expected, received)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package user_agent
+
+import "strings"
+
+// frozenOSVersions lists the operating system versions that browsers report regardless of the actual version.
+// Safari and Chrome report macOS 10_15_7, and Firefox reports macOS 10.15, on every release since Catalina.
+var frozenOSVersions = map[OSName][]string{
+	OSNameMacOS: {"10.15.7", "10.15"},
+}
+
+// setFrozen flags the values frozen by browsers to reduce fingerprinting. Chrome's User-Agent reduction replaces the
+// minor, build, and patch versions with 0.0.0 (e.g. Chrome/110.0.0.0), and on Android, it reports the operating
+// system as Android 10 and the device model as K. The actual values are only available from the Client Hints.
+func (ua *UserAgent) setFrozen() {
+	reducedAndroid := isReducedAndroid(ua.Products)
+	ua.Reduced = isReducedVersion(ua.Products) || reducedAndroid
+	ua.OSVersionFrozen = false
+	for _, ver := range frozenOSVersions[ua.OSName] {
+		if ua.OSVersionFull == ver {
+			ua.OSVersionFrozen = true
+		}
+	}
+	// A reduced Chrome version alone doesn't freeze a genuine Android 10 device (e.g. "Android 10; Pixel 4")
+	if reducedAndroid && ua.OSName == OSNameAndroid && ua.OSVersionFull == "10" {
+		ua.OSVersionFrozen = true
+	}
+}
+
+// isReducedVersion returns true if the User-Agent string has a reduced Chrome version (e.g. Chrome/110.0.0.0).
+func isReducedVersion(products []Product) bool {
+	ver, ok := productVersion(products, "Chrome")
+	major, rest, _ := strings.Cut(ver, ".")
+	return ok && isDigits(major) && rest == "0.0.0"
+}
+
+// isReducedAndroid returns true if the User-Agent string has the reduced Android comment ("Linux; Android 10; K").
+func isReducedAndroid(products []Product) bool {
+	for _, p := range products {
+		for _, c := range p.Comments {
+			android := false // the previous segment is the Android version
+			for s, rest, more := "", c, true; more; {
+				s, rest, more = strings.Cut(rest, ";")
+				s = strings.TrimSpace(s)
+				if android && s == "K" {
+					return true
+				}
+				android = strings.HasPrefix(s, "Android")
+			}
+		}
+	}
+	return false
+}
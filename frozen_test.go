@@ -0,0 +1,47 @@
+package user_agent
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFrozen(t *testing.T) {
+	cases := []struct {
+		name    string
+		ua      string
+		frozen  bool
+		reduced bool
+	}{
+		{"Safari", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.1 Safari/605.1.15", true, false},
+		{"Firefox", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:106.0) Gecko/20100101 Firefox/106.0", true, false},
+		{"Mojave", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_6) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.1.2 Safari/605.1.15", false, false},
+		{"ChromeMac", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36", true, true},
+		{"ChromeWindows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36", false, true},
+		{"ChromeFull", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36", false, false},
+		{"ChromeAndroid", "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36", true, true},
+		{"ChromeAndroid10Model", "Mozilla/5.0 (Linux; Android 10; Pixel 4) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/105.0.0.0 Mobile Safari/537.36", false, true},
+		{"ChromeAndroidModel", "Mozilla/5.0 (Linux; Android 10; SM-G973U) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.61 Mobile Safari/537.36", false, false},
+		{"iPhone", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1", false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			if ua.OSVersionFrozen != c.frozen || ua.Reduced != c.reduced {
+				t.Errorf("expected/received:\nfrozen %t reduced %t\nfrozen %t reduced %t",
+					c.frozen, c.reduced, ua.OSVersionFrozen, ua.Reduced)
+			}
+		})
+	}
+}
+
+// TestFrozen_hints checks that the operating system version is no longer frozen when the Client Hints provide it.
+func TestFrozen_hints(t *testing.T) {
+	h := http.Header{}
+	h.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36")
+	h.Set("Sec-CH-UA-Platform", `"macOS"`)
+	h.Set("Sec-CH-UA-Platform-Version", `"13.2.1"`)
+	ua := ParseHeaders(h)
+	if ua.OSVersionFrozen || !ua.Reduced || ua.OSVersion != "13.2" {
+		t.Errorf("unexpected frozen %t, reduced %t, version %s", ua.OSVersionFrozen, ua.Reduced, ua.OSVersion)
+	}
+}
@@ -0,0 +1,139 @@
+package user_agent
+
+import "strings"
+
+// botKeywords are the (lower-case) words that indicate a bot in a product name (e.g. MyCompanyCrawler/2.1).
+var botKeywords = []string{"bot", "crawler", "spider", "scraper", "fetcher", "monitor", "preview", "checker"}
+
+// genericProducts are the product names used by browsers, which never identify a bot.
+var genericProducts = map[string]bool{
+	"Mozilla": true, "AppleWebKit": true, "Gecko": true, "Chrome": true, "Safari": true, "Version": true,
+	"Mobile": true, "like": true,
+}
+
+// applyHeuristics identifies bots that aren't recognized by the pattern matchers. It's a fallback, used only when the
+// matchers found a browser (or nothing at all), or found a bot URL without a bot name (e.g. the contact URL of a
+// crawler built on an HTTP client library). A bot is indicated by a bot keyword in a product name, or a contact e-mail
+// address or "+http" marker in a comment (a URL is handled earlier). The bot's own product token provides the
+// ClientName and ClientVersion, and the Heuristic flag is set.
+//
+// To avoid false positives, keywords are only found in product names (e.g. MyCompanyCrawler/2.1), in Name/Version
+// comment segments (e.g. "compatible; FooBot/1.0"), and in single-word comment segments of "compatible" comments or
+// comments with a contact marker (e.g. "compatible; Bytespider; spider-feedback@bytedance.com"). For example,
+// "(KHTML, like Gecko; Google Web Preview)" doesn't indicate a bot.
+func (ua *UserAgent) applyHeuristics() {
+	if ua.ClientType == ClientTypeApp || ua.ClientType == ClientTypeLibrary ||
+		(ua.ClientType == ClientTypeBot && ua.ClientName != "") {
+		return
+	}
+	name, ver, found := keywordBot(ua.Products)
+	if !found {
+		if ua.ClientType != ClientTypeBot && !hasContactMarker(ua.Header) {
+			return
+		}
+		if ua.ClientName != "" {
+			ua.ClientType = ClientTypeBot // e.g. a browser with a contact e-mail address
+			ua.Heuristic = true
+			return
+		}
+		name, ver = botProduct(ua.Products)
+		if name == "" {
+			ua.ClientType = ClientTypeBot
+			ua.Heuristic = true
+			return
+		}
+	}
+	ua.ClientType = ClientTypeBot
+	ua.ClientName = name
+	ua.setClientVersion(ver)
+	ua.Heuristic = true
+}
+
+// keywordBot returns the name and version of the first product or comment segment with a bot keyword, if any.
+func keywordBot(products []Product) (name, ver string, found bool) {
+	for _, p := range products {
+		if hasBotKeyword(p.Name) && !strings.Contains(p.Name, "://") {
+			return p.Name, p.Version, true
+		}
+		for _, c := range p.Comments {
+			trusted := hasContactMarker(c)
+			for s, rest, more := "", c, true; more && !trusted; {
+				s, rest, more = strings.Cut(rest, ";")
+				trusted = strings.TrimSpace(s) == "compatible"
+			}
+			for s, rest, more := "", c, true; more; {
+				s, rest, more = strings.Cut(rest, ";")
+				s = strings.TrimSpace(s)
+				if strings.ContainsAny(s, " @") || strings.HasPrefix(s, "+") {
+					continue
+				}
+				name, ver, slash := strings.Cut(s, "/")
+				if hasBotKeyword(name) && (slash || trusted) {
+					return name, ver, true
+				}
+			}
+		}
+	}
+	return "", "", false
+}
+
+// botProduct returns the name and version of the first product that isn't a generic browser product, if any.
+func botProduct(products []Product) (name, ver string) {
+	for _, p := range products {
+		if p.Name != "" && !genericProducts[p.Name] && !strings.Contains(p.Name, "://") {
+			return p.Name, p.Version
+		}
+	}
+	return "", ""
+}
+
+// hasBotKeyword returns true if the supplied name contains a bot keyword, ignoring case.
+func hasBotKeyword(name string) bool {
+	for _, k := range botKeywords {
+		for i := 0; i+len(k) <= len(name); i++ {
+			if strings.EqualFold(name[i:i+len(k)], k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasContactMarker returns true if the supplied text contains a "+http" marker or an e-mail address, which bots
+// provide so that site operators can contact them.
+func hasContactMarker(text string) bool {
+	if strings.Contains(text, "+http") {
+		return true
+	}
+	for i := strings.IndexByte(text, '@'); i >= 0; {
+		local := strings.LastIndexAny(text[:i], " ;(),+<") + 1
+		domain := text[i+1:]
+		if end := strings.IndexAny(domain, " ;(),>"); end >= 0 {
+			domain = domain[:end]
+		}
+		if i > local && isMailDomain(strings.Trim(domain, ".")) {
+			return true
+		}
+		next := strings.IndexByte(text[i+1:], '@')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return false
+}
+
+// isMailDomain returns true if the supplied text looks like an e-mail domain, with an alphabetic top-level domain
+// (e.g. example.com, but not the version number in "Ecosia android@88.0.4324.181").
+func isMailDomain(domain string) bool {
+	i := strings.LastIndexByte(domain, '.')
+	if i <= 0 || i == len(domain)-1 {
+		return false
+	}
+	for _, r := range domain[i+1:] {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package user_agent
+
+import "testing"
+
+func TestHeuristics(t *testing.T) {
+	cases := []struct {
+		name      string
+		ua        string
+		expected  string
+		heuristic bool
+	}{
+		{"ProductKeyword", "MyCompanyCrawler/2.1 (contact@example.com)", "Bot MyCompanyCrawler 2.1 Desktop Other", true},
+		{"CommentKeyword", "Mozilla/5.0 (compatible; AcmeSpider/3.0.1; +https://acme.example/spider)", "Bot AcmeSpider 3.0 Desktop Other https://acme.example/spider", true},
+		{"CompatibleSegment", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko; compatible; Linkchecker) Chrome/110.0.0.0 Safari/537.36", "Bot Linkchecker Desktop macOS 10.15", true},
+		{"ContactEmail", "FeedReader/1.4 (ops@feeds.example.org)", "Bot FeedReader 1.4 Desktop Other", true},
+		{"ContactPlusHttp", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.64 Safari/537.36 (+http)", "Bot Chrome 101.0 Desktop Windows 10.0", true},
+		{"KnownBot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", "Bot Googlebot 2.1 Desktop Other http://www.google.com/bot.html", false},
+		{"WebPreview", "Mozilla/5.0 (Windows NT 6.1) AppleWebKit/537.36 (KHTML, like Gecko; Google Web Preview) Chrome/27.0.1453 Safari/537.36", "Browser Chrome 27.0 Desktop Windows 6.1", false},
+		{"VersionEmail", "Mozilla/5.0 (Linux; Android 12; SM-G998B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/88.0.4324.181 Mobile Safari/537.36 (Ecosia android@88.0.4324.181)", "Browser Chrome 88.0 Mobile Android 12", false},
+		{"Browser", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0", "Browser Firefox 102.0 Desktop Windows 10.0", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			if ua.String() != c.expected || ua.Heuristic != c.heuristic {
+				t.Errorf("expected/received:\n%s %t\n%s %t", c.expected, c.heuristic, ua.String(), ua.Heuristic)
+			}
+		})
+	}
+}
@@ -0,0 +1,558 @@
+package user_agent
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// brandNames maps User-Agent Client Hint brands to the client names used by Parse. The generic "Chromium" brand
+// is only used when no more specific brand is provided.
+var brandNames = map[string]string{
+	"Google Chrome":    "Chrome",
+	"Microsoft Edge":   "Edge",
+	"Opera":            "Opera",
+	"Opera GX":         "Opera",
+	"Samsung Internet": "SamsungBrowser",
+	"DuckDuckGo":       "DuckDuckGo",
+	"Brave":            "Brave",
+	"Yandex":           "Yandex",
+	"Whale":            "Whale",
+}
+
+// platformNames maps Sec-CH-UA-Platform values to the operating system names used by Parse.
+var platformNames = map[string]OSName{
+	"Android":     OSNameAndroid,
+	"Chrome OS":   OSNameChromeOS,
+	"Chromium OS": OSNameChromeOS,
+	"iOS":         OSNameIOS,
+	"Linux":       OSNameLinux,
+	"macOS":       OSNameMacOS,
+	"Windows":     OSNameWindows,
+}
+
+// ClientHints contains the User-Agent Client Hints (the Sec-CH-UA family of request headers) provided by a browser.
+// Chromium-based browsers have frozen most of the User-Agent string, and provide the detailed information in these
+// headers instead. For more on Client Hints, see: https://wicg.github.io/ua-client-hints/
+type ClientHints struct {
+	// Brands contains the brands and significant versions from the Sec-CH-UA header (GREASE brands are discarded)
+	Brands []Brand `json:"brands,omitempty"`
+
+	// FullVersionList contains the brands and full versions from the Sec-CH-UA-Full-Version-List header
+	FullVersionList []Brand `json:"fullVersionList,omitempty"`
+
+	// Platform indicates the operating system name from the Sec-CH-UA-Platform header (Windows, macOS, etc.)
+	Platform string `json:"platform,omitempty"`
+
+	// PlatformVersion indicates the operating system version from the Sec-CH-UA-Platform-Version header
+	PlatformVersion string `json:"platformVersion,omitempty"`
+
+	// Mobile indicates the Sec-CH-UA-Mobile header value, or nil if it was not provided
+	Mobile *bool `json:"mobile,omitempty"`
+
+	// Model indicates the device model from the Sec-CH-UA-Model header
+	Model string `json:"model,omitempty"`
+
+	// Arch indicates the CPU architecture from the Sec-CH-UA-Arch header (x86, arm, etc.)
+	Arch string `json:"arch,omitempty"`
+
+	// Bitness indicates the CPU architecture bitness from the Sec-CH-UA-Bitness header (32 or 64)
+	Bitness string `json:"bitness,omitempty"`
+}
+
+// Brand is a single entry in a Sec-CH-UA or Sec-CH-UA-Full-Version-List header.
+type Brand struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ParseClientHints extracts the User-Agent Client Hints from the provided HTTP request headers.
+// Headers that are missing or malformed are ignored.
+func ParseClientHints(h http.Header) ClientHints {
+	var hints ClientHints
+	hints.Brands = parseBrands(headerValue(h, "Sec-CH-UA"))
+	hints.FullVersionList = parseBrands(headerValue(h, "Sec-CH-UA-Full-Version-List"))
+	hints.Platform = sfString(headerValue(h, "Sec-CH-UA-Platform"))
+	hints.PlatformVersion = sfString(headerValue(h, "Sec-CH-UA-Platform-Version"))
+	hints.Model = sfString(headerValue(h, "Sec-CH-UA-Model"))
+	hints.Arch = sfString(headerValue(h, "Sec-CH-UA-Arch"))
+	hints.Bitness = sfString(headerValue(h, "Sec-CH-UA-Bitness"))
+	if item, err := parseSFItem(headerValue(h, "Sec-CH-UA-Mobile")); err == nil {
+		if b, ok := item.value.(bool); ok {
+			hints.Mobile = &b
+		}
+	}
+	return hints
+}
+
+// ParseHeaders extracts client, device, and operating system information from the User-Agent request header and
+// any User-Agent Client Hints headers provided, returning a UserAgent.
+func ParseHeaders(h http.Header) UserAgent {
+	return defaultParser.ParseHeaders(h)
+}
+
+// ParseWithHints extracts client, device, and operating system information from the User-Agent request header
+// provided, using the supplied Client Hints to fill in or override the client name and version, the operating
+// system name and version, and the device type.
+func ParseWithHints(userAgent string, hints ClientHints) UserAgent {
+	return defaultParser.ParseWithHints(userAgent, hints)
+}
+
+// applyHints updates the UserAgent with information from the Client Hints, which is more reliable than the
+// (frozen) User-Agent header. Bots, applications, and libraries keep their client information.
+func applyHints(ua *UserAgent, hints ClientHints) {
+	if ua.ClientType == ClientTypeBrowser || ua.ClientType == ClientTypeOther {
+		if name, ver := hintsClient(hints); name != "" {
+			// keep the User-Agent version if the hints only provide a less precise version of it
+			if name != ua.ClientName || (ver != "" && !strings.HasPrefix(ua.ClientVersionNumber.Raw+".", ver+".")) {
+				ua.setClientVersion(ver)
+			}
+			ua.ClientType = ClientTypeBrowser
+			ua.ClientName = name
+		}
+	}
+	if osName, ok := platformNames[hints.Platform]; ok {
+		if osName != ua.OSName {
+			ua.setOSVersion("")
+			ua.OSVersionFrozen = false
+		}
+		ua.OSName = osName
+		if ver, release := platformVersion(osName, hints.PlatformVersion); ver != "" {
+			ua.setOSVersion(ver)
+			ua.OSVersionFrozen = false
+			if release != "" {
+				ua.OSVersionName = release
+			}
+		}
+	}
+	ua.applyArchitectureHints(hints.Arch, hints.Bitness)
+	ua.setFamily()
+	if hints.Model != "" {
+		ua.setDeviceModel(hints.Model, "")
+	}
+	if hints.Mobile != nil {
+		if *hints.Mobile {
+			ua.DeviceType = DeviceTypeMobile
+		} else if ua.DeviceType == DeviceTypeMobile {
+			if ua.OSName == OSNameAndroid {
+				ua.DeviceType = DeviceTypeTablet
+			} else {
+				ua.DeviceType = DeviceTypeDesktop
+			}
+		}
+	}
+}
+
+// hintsClient returns the client name and version indicated by the Client Hints brands, preferring a specific
+// brand from either header over the generic "Chromium" brand (the full version list may be partial), and a full
+// version over a significant version.
+func hintsClient(hints ClientHints) (string, string) {
+	brand, name := "", ""
+	for _, list := range [][]Brand{hints.FullVersionList, hints.Brands} {
+		for _, b := range list {
+			if n, ok := brandNames[b.Name]; ok && brand == "" {
+				brand, name = b.Name, n
+			}
+		}
+	}
+	if brand == "" {
+		for _, list := range [][]Brand{hints.FullVersionList, hints.Brands} {
+			for _, b := range list {
+				if b.Name == "Chromium" {
+					brand, name = b.Name, "Chrome"
+				}
+			}
+		}
+	}
+	if brand == "" {
+		return "", ""
+	}
+	for _, b := range hints.FullVersionList {
+		if b.Name == brand {
+			if b.Version != "" {
+				return name, b.Version
+			}
+		}
+	}
+	for _, b := range hints.Brands {
+		if b.Name == brand {
+			return name, b.Version
+		}
+	}
+	return name, ""
+}
+
+// platformVersion returns the operating system version indicated by the Sec-CH-UA-Platform-Version header, and the
+// name of the release, if it isn't implied by the version. On Windows, the platform version is not the NT kernel
+// version: versions 1-10 indicate Windows 10, and versions 13 and above indicate Windows 11, which is still NT 10.0.
+// Version 0 indicates an earlier release, which the User-Agent reports.
+func platformVersion(osName OSName, ver string) (string, string) {
+	if !isDigits(ver) {
+		return "", ""
+	}
+	if osName != OSNameWindows {
+		return ver, ""
+	}
+	n := ParseVersion(ver).Major
+	if n == 0 {
+		return "", ""
+	}
+	if n >= 13 {
+		return "10.0", "11"
+	}
+	return "10.0", ""
+}
+
+// headerValue returns all values of the named header, combined into a single comma-separated list.
+func headerValue(h http.Header, name string) string {
+	return strings.Join(h.Values(name), ",")
+}
+
+// isGreaseBrand returns true if the brand is an intentionally-invalid GREASE brand (e.g. "Not A;Brand"), which
+// browsers include to ensure that servers don't depend on a particular list of brands.
+func isGreaseBrand(name string) bool {
+	name = strings.TrimSpace(name)
+	return strings.HasPrefix(name, "Not") && strings.HasSuffix(name, "Brand")
+}
+
+// parseBrands parses a brand list header (e.g. `"Chromium";v="110", "Not A(Brand";v="24"`), discarding GREASE
+// brands. A malformed header yields no brands.
+func parseBrands(s string) []Brand {
+	items, err := parseSFList(s)
+	if err != nil {
+		return nil
+	}
+	var brands []Brand
+	for _, item := range items {
+		name, ok := item.value.(string)
+		if !ok || isGreaseBrand(name) {
+			continue
+		}
+		b := Brand{Name: name}
+		if v, ok := item.param("v").(string); ok {
+			b.Version = v
+		}
+		brands = append(brands, b)
+	}
+	return brands
+}
+
+// sfString returns the value of a structured field string header (e.g. `"Windows"`), or an empty string.
+func sfString(s string) string {
+	item, err := parseSFItem(s)
+	if err != nil {
+		return ""
+	}
+	v, _ := item.value.(string)
+	return v
+}
+
+// sfItem is a structured field item or inner list, as defined in RFC 8941. The value is a string, token, int64,
+// float64, bool, byte sequence ([]byte), or inner list ([]sfItem).
+type sfItem struct {
+	value  any
+	params []sfParam
+}
+
+// sfParam is a structured field parameter.
+type sfParam struct {
+	key   string
+	value any
+}
+
+// param returns the value of the named parameter, or nil if it's not present.
+func (i sfItem) param(key string) any {
+	for _, p := range i.params {
+		if p.key == key {
+			return p.value
+		}
+	}
+	return nil
+}
+
+// sfToken is a structured field token, distinguished from a string.
+type sfToken string
+
+// errStructuredField indicates a malformed structured field header.
+var errStructuredField = errors.New("malformed structured field")
+
+// sfParser parses structured field header values, as defined in RFC 8941.
+type sfParser struct {
+	s string
+	i int
+}
+
+// parseSFList parses a structured field list. An empty header yields an empty list.
+func parseSFList(s string) ([]sfItem, error) {
+	p := &sfParser{s: s}
+	p.skipSP()
+	var items []sfItem
+	for p.i < len(p.s) {
+		item, err := p.parseItemOrInnerList()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		p.skipOWS()
+		if p.i >= len(p.s) {
+			return items, nil
+		}
+		if p.s[p.i] != ',' {
+			return nil, errStructuredField
+		}
+		p.i++
+		p.skipOWS()
+		if p.i >= len(p.s) {
+			return nil, errStructuredField // trailing comma
+		}
+	}
+	return items, nil
+}
+
+// parseSFItem parses a structured field item.
+func parseSFItem(s string) (sfItem, error) {
+	p := &sfParser{s: s}
+	p.skipSP()
+	item, err := p.parseItem()
+	if err != nil {
+		return sfItem{}, err
+	}
+	p.skipSP()
+	if p.i != len(p.s) {
+		return sfItem{}, errStructuredField
+	}
+	return item, nil
+}
+
+func (p *sfParser) skipSP() {
+	for p.i < len(p.s) && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+func (p *sfParser) skipOWS() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *sfParser) parseItemOrInnerList() (sfItem, error) {
+	if p.i < len(p.s) && p.s[p.i] == '(' {
+		return p.parseInnerList()
+	}
+	return p.parseItem()
+}
+
+func (p *sfParser) parseInnerList() (sfItem, error) {
+	p.i++ // consume '('
+	var items []sfItem
+	for p.i < len(p.s) {
+		p.skipSP()
+		if p.i < len(p.s) && p.s[p.i] == ')' {
+			p.i++
+			params, err := p.parseParams()
+			if err != nil {
+				return sfItem{}, err
+			}
+			return sfItem{value: items, params: params}, nil
+		}
+		item, err := p.parseItem()
+		if err != nil {
+			return sfItem{}, err
+		}
+		items = append(items, item)
+		if p.i < len(p.s) && p.s[p.i] != ' ' && p.s[p.i] != ')' {
+			return sfItem{}, errStructuredField
+		}
+	}
+	return sfItem{}, errStructuredField // unterminated inner list
+}
+
+func (p *sfParser) parseItem() (sfItem, error) {
+	v, err := p.parseBareItem()
+	if err != nil {
+		return sfItem{}, err
+	}
+	params, err := p.parseParams()
+	if err != nil {
+		return sfItem{}, err
+	}
+	return sfItem{value: v, params: params}, nil
+}
+
+func (p *sfParser) parseParams() ([]sfParam, error) {
+	var params []sfParam
+	for p.i < len(p.s) && p.s[p.i] == ';' {
+		p.i++
+		p.skipSP()
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		var v any = true
+		if p.i < len(p.s) && p.s[p.i] == '=' {
+			p.i++
+			if v, err = p.parseBareItem(); err != nil {
+				return nil, err
+			}
+		}
+		params = append(params, sfParam{key: key, value: v})
+	}
+	return params, nil
+}
+
+func (p *sfParser) parseKey() (string, error) {
+	start := p.i
+	if p.i >= len(p.s) || !(isLCAlpha(p.s[p.i]) || p.s[p.i] == '*') {
+		return "", errStructuredField
+	}
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if !(isLCAlpha(c) || isDigit(c) || c == '_' || c == '-' || c == '.' || c == '*') {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i], nil
+}
+
+func (p *sfParser) parseBareItem() (any, error) {
+	if p.i >= len(p.s) {
+		return nil, errStructuredField
+	}
+	c := p.s[p.i]
+	switch {
+	case c == '-' || isDigit(c):
+		return p.parseNumber()
+	case c == '"':
+		return p.parseString()
+	case c == '*' || isAlpha(c):
+		return p.parseToken()
+	case c == ':':
+		return p.parseByteSequence()
+	case c == '?':
+		return p.parseBoolean()
+	}
+	return nil, errStructuredField
+}
+
+func (p *sfParser) parseNumber() (any, error) {
+	start := p.i
+	if p.s[p.i] == '-' {
+		p.i++
+	}
+	digits, decimal := 0, false
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if isDigit(c) {
+			digits++
+		} else if c == '.' && !decimal && digits > 0 && digits <= 12 {
+			decimal = true
+		} else {
+			break
+		}
+		p.i++
+	}
+	num := p.s[start:p.i]
+	if digits == 0 || strings.HasSuffix(num, ".") {
+		return nil, errStructuredField
+	}
+	if decimal {
+		f, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return nil, errStructuredField
+		}
+		return f, nil
+	}
+	if digits > 15 {
+		return nil, errStructuredField
+	}
+	n, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return nil, errStructuredField
+	}
+	return n, nil
+}
+
+func (p *sfParser) parseString() (any, error) {
+	p.i++ // consume opening quote
+	var b strings.Builder
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		p.i++
+		switch {
+		case c == '\\':
+			if p.i >= len(p.s) || (p.s[p.i] != '"' && p.s[p.i] != '\\') {
+				return nil, errStructuredField
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case c == '"':
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			return nil, errStructuredField
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return nil, errStructuredField // unterminated string
+}
+
+func (p *sfParser) parseToken() (any, error) {
+	start := p.i
+	p.i++
+	for p.i < len(p.s) && (isTChar(p.s[p.i]) || p.s[p.i] == ':' || p.s[p.i] == '/') {
+		p.i++
+	}
+	return sfToken(p.s[start:p.i]), nil
+}
+
+func (p *sfParser) parseByteSequence() (any, error) {
+	p.i++ // consume opening colon
+	end := strings.IndexByte(p.s[p.i:], ':')
+	if end == -1 {
+		return nil, errStructuredField
+	}
+	encoded := p.s[p.i : p.i+end]
+	p.i += end + 1
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errStructuredField
+	}
+	return b, nil
+}
+
+func (p *sfParser) parseBoolean() (any, error) {
+	p.i++ // consume question mark
+	if p.i >= len(p.s) {
+		return nil, errStructuredField
+	}
+	c := p.s[p.i]
+	p.i++
+	switch c {
+	case '1':
+		return true, nil
+	case '0':
+		return false, nil
+	}
+	return nil, errStructuredField
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLCAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isAlpha(c byte) bool {
+	return isLCAlpha(c) || (c >= 'A' && c <= 'Z')
+}
+
+// isTChar returns true if the character is a valid token character, as defined in RFC 9110.
+func isTChar(c byte) bool {
+	return isAlpha(c) || isDigit(c) || strings.IndexByte("!#$%&'*+-.^_`|~", c) != -1
+}
@@ -0,0 +1,199 @@
+package user_agent
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_parseSFList(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected []sfItem
+		valid    bool
+	}{
+		{"empty", "", nil, true},
+		{"strings", `"a", "b"`, []sfItem{{value: "a"}, {value: "b"}}, true},
+		{"escapes", `"a\"b\\c"`, []sfItem{{value: `a"b\c`}}, true},
+		{"parameters", `"Chromium";v="110";x`, []sfItem{{value: "Chromium", params: []sfParam{{"v", "110"}, {"x", true}}}}, true},
+		{"numbers", `1, -2, 3.5`, []sfItem{{value: int64(1)}, {value: int64(-2)}, {value: 3.5}}, true},
+		{"token and boolean", `gzip, ?0`, []sfItem{{value: sfToken("gzip")}, {value: false}}, true},
+		{"byte sequence", `:aGVsbG8=:`, []sfItem{{value: []byte("hello")}}, true},
+		{"inner list", `("a" "b");q=1, c`, []sfItem{{value: []sfItem{{value: "a"}, {value: "b"}}, params: []sfParam{{"q", int64(1)}}}, {value: sfToken("c")}}, true},
+		{"trailing comma", `"a",`, nil, false},
+		{"unterminated string", `"a`, nil, false},
+		{"invalid escape", `"a\b"`, nil, false},
+		{"missing comma", `"a" "b"`, nil, false},
+		{"invalid boolean", `?2`, nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			items, err := parseSFList(c.input)
+			if (err == nil) != c.valid {
+				t.Fatalf("expected valid=%t, received error: %v", c.valid, err)
+			}
+			if !reflect.DeepEqual(items, c.expected) {
+				t.Errorf("expected/received:\n%#v\n%#v", c.expected, items)
+			}
+		})
+	}
+}
+
+func Test_parseBrands(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected []Brand
+	}{
+		{"empty", "", nil},
+		{"chrome", `" Not A;Brand";v="99", "Chromium";v="101", "Google Chrome";v="101"`, []Brand{{"Chromium", "101"}, {"Google Chrome", "101"}}},
+		{"edge", `"Microsoft Edge";v="110", "Not A(Brand";v="24", "Chromium";v="110"`, []Brand{{"Microsoft Edge", "110"}, {"Chromium", "110"}}},
+		{"full versions", `"Not/A)Brand";v="8.0.0.0", "Chromium";v="126.0.6478.127", "Google Chrome";v="126.0.6478.127"`, []Brand{{"Chromium", "126.0.6478.127"}, {"Google Chrome", "126.0.6478.127"}}},
+		{"malformed", `"Chromium";v="101", "Google Chrome`, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			brands := parseBrands(c.input)
+			if !reflect.DeepEqual(brands, c.expected) {
+				t.Errorf("expected/received:\n%v\n%v", c.expected, brands)
+			}
+		})
+	}
+}
+
+func TestParseClientHints(t *testing.T) {
+	h := http.Header{}
+	h.Set("Sec-CH-UA", `"Chromium";v="110", "Not A(Brand";v="24", "Google Chrome";v="110"`)
+	h.Set("Sec-CH-UA-Full-Version-List", `"Chromium";v="110.0.5481.177", "Not A(Brand";v="24.0.0.0", "Google Chrome";v="110.0.5481.177"`)
+	h.Set("Sec-CH-UA-Platform", `"Android"`)
+	h.Set("Sec-CH-UA-Platform-Version", `"13.0.0"`)
+	h.Set("Sec-CH-UA-Mobile", "?1")
+	h.Set("Sec-CH-UA-Model", `"Pixel 7"`)
+	h.Set("Sec-CH-UA-Arch", `""`)
+	h.Set("Sec-CH-UA-Bitness", `"64"`)
+	mobile := true
+	expected := ClientHints{
+		Brands:          []Brand{{"Chromium", "110"}, {"Google Chrome", "110"}},
+		FullVersionList: []Brand{{"Chromium", "110.0.5481.177"}, {"Google Chrome", "110.0.5481.177"}},
+		Platform:        "Android",
+		PlatformVersion: "13.0.0",
+		Mobile:          &mobile,
+		Model:           "Pixel 7",
+		Bitness:         "64",
+	}
+	hints := ParseClientHints(h)
+	if !reflect.DeepEqual(hints, expected) {
+		t.Errorf("expected/received:\n%+v\n%+v", expected, hints)
+	}
+}
+
+// TestParseHeaders tests User-Agent strings accompanied by User-Agent Client Hints.
+// Reference: https://developer.mozilla.org/en-US/docs/Web/HTTP/Client_hints#user-agent_client_hints
+func TestParseHeaders(t *testing.T) {
+	cases := []struct {
+		name     string
+		headers  map[string]string
+		expected string
+	}{
+		{
+			name: "Windows 11",
+			headers: map[string]string{
+				"User-Agent":                  "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36",
+				"Sec-CH-UA":                   `"Chromium";v="110", "Not A(Brand";v="24", "Google Chrome";v="110"`,
+				"Sec-CH-UA-Full-Version-List": `"Chromium";v="110.0.5481.177", "Not A(Brand";v="24.0.0.0", "Google Chrome";v="110.0.5481.177"`,
+				"Sec-CH-UA-Platform":          `"Windows"`,
+				"Sec-CH-UA-Platform-Version":  `"15.0.0"`,
+				"Sec-CH-UA-Mobile":            "?0",
+			},
+			expected: "Browser Chrome 110.0 Desktop Windows 10.0",
+		},
+		{
+			name: "Windows 10",
+			headers: map[string]string{
+				"User-Agent":                 "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36 Edg/110.0.1587.57",
+				"Sec-CH-UA":                  `"Chromium";v="110", "Not A(Brand";v="24", "Microsoft Edge";v="110"`,
+				"Sec-CH-UA-Platform":         `"Windows"`,
+				"Sec-CH-UA-Platform-Version": `"10.0.0"`,
+			},
+			expected: "Browser Edge 110.0 Desktop Windows 10.0",
+		},
+		{
+			name: "Partial full version list",
+			headers: map[string]string{
+				"User-Agent":                  "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36",
+				"Sec-CH-UA":                   `"Chromium";v="110", "Not A(Brand";v="24", "Opera";v="96"`,
+				"Sec-CH-UA-Full-Version-List": `"Chromium";v="110.0.5481.178"`,
+				"Sec-CH-UA-Platform":          `"Windows"`,
+			},
+			expected: "Browser Opera 96 Desktop Windows 10.0",
+		},
+		{
+			name: "Windows 7",
+			headers: map[string]string{
+				"User-Agent":                 "Mozilla/5.0 (Windows NT 6.1; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/109.0.0.0 Safari/537.36",
+				"Sec-CH-UA":                  `"Not_A Brand";v="99", "Google Chrome";v="109", "Chromium";v="109"`,
+				"Sec-CH-UA-Platform":         `"Windows"`,
+				"Sec-CH-UA-Platform-Version": `"0.1.0"`,
+			},
+			expected: "Browser Chrome 109.0 Desktop Windows 6.1",
+		},
+		{
+			name: "macOS",
+			headers: map[string]string{
+				"User-Agent":                  "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+				"Sec-CH-UA-Full-Version-List": `"Not/A)Brand";v="8.0.0.0", "Chromium";v="126.0.6478.127", "Google Chrome";v="126.0.6478.127"`,
+				"Sec-CH-UA-Platform":          `"macOS"`,
+				"Sec-CH-UA-Platform-Version":  `"14.5.0"`,
+			},
+			expected: "Browser Chrome 126.0 Desktop macOS 14.5",
+		},
+		{
+			name: "Android tablet",
+			headers: map[string]string{
+				"User-Agent":                 "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36",
+				"Sec-CH-UA":                  `"Chromium";v="110", "Not A(Brand";v="24", "Google Chrome";v="110"`,
+				"Sec-CH-UA-Platform":         `"Android"`,
+				"Sec-CH-UA-Platform-Version": `"13.0.0"`,
+				"Sec-CH-UA-Mobile":           "?0",
+			},
+			expected: "Browser Chrome 110.0 Tablet Android 13.0",
+		},
+		{
+			name: "Chromium only",
+			headers: map[string]string{
+				"User-Agent": "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36",
+				"Sec-CH-UA":  `"Chromium";v="110", "Not A(Brand";v="24"`,
+			},
+			expected: "Browser Chrome 110.0 Desktop Linux",
+		},
+		{
+			name: "bot",
+			headers: map[string]string{
+				"User-Agent":         "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/110.0.5481.177 Safari/537.36",
+				"Sec-CH-UA":          `"Chromium";v="110", "Not A(Brand";v="24", "HeadlessChrome";v="110"`,
+				"Sec-CH-UA-Platform": `"Linux"`,
+			},
+			expected: "Bot Chrome 110.0 Desktop Linux",
+		},
+		{
+			name: "no hints",
+			headers: map[string]string{
+				"User-Agent": "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0",
+			},
+			expected: "Browser Firefox 102.0 Desktop Windows 10.0",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range c.headers {
+				h.Set(k, v)
+			}
+			s := ParseHeaders(h).String()
+			if s != c.expected {
+				t.Errorf("expected/received:\n%s\n%s", c.expected, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package user_agent
+
+import "math/bits"
+
+// matcher is an Aho-Corasick automaton, which finds all occurrences of a set of patterns in a single pass over the
+// text, regardless of the number of patterns. To keep the transition table small, bytes are mapped to equivalence
+// classes: each byte appearing in a pattern has its own class, and all other bytes share class 0. The classes are
+// wider than a byte, so that patterns using all 256 byte values still have distinct classes.
+type matcher struct {
+	classes    [256]uint16 // byte to equivalence class
+	numClasses int         // number of equivalence classes, including class 0
+	next       []int32     // transition table: next[state*numClasses+class] is the next state
+	outputs    [][]int32   // outputs[state] lists the patterns ending at this state, including via suffix links
+	lengths    []int       // pattern lengths, in bytes
+	empty      []int32     // empty patterns, which match any text
+}
+
+// hit is a pattern found in the text, identified by its index in the pattern list and its starting byte offset.
+type hit struct {
+	pattern int
+	start   int
+}
+
+// newMatcher compiles the supplied patterns into an automaton. Duplicate and empty patterns are permitted.
+func newMatcher(patterns []string) *matcher {
+	m := &matcher{lengths: make([]int, len(patterns))}
+	// Assign an equivalence class to each byte used in the patterns
+	for _, p := range patterns {
+		for i := 0; i < len(p); i++ {
+			if m.classes[p[i]] == 0 {
+				m.numClasses++
+				m.classes[p[i]] = uint16(m.numClasses)
+			}
+		}
+	}
+	m.numClasses++ // class 0
+	// Build a trie of the patterns, with -1 indicating a missing transition
+	k := m.numClasses
+	m.next = newRow(nil, k)
+	m.outputs = [][]int32{nil}
+	for i, p := range patterns {
+		m.lengths[i] = len(p)
+		if p == "" {
+			m.empty = append(m.empty, int32(i))
+			continue
+		}
+		state := int32(0)
+		for j := 0; j < len(p); j++ {
+			t := int(state)*k + int(m.classes[p[j]])
+			if m.next[t] == -1 {
+				m.next[t] = int32(len(m.outputs))
+				m.next = newRow(m.next, k)
+				m.outputs = append(m.outputs, nil)
+			}
+			state = m.next[t]
+		}
+		m.outputs[state] = append(m.outputs[state], int32(i))
+	}
+	// Compute failure links breadth-first, replacing missing transitions with the failure state's transitions, so
+	// that the scan never backtracks. Outputs are merged along the failure links.
+	fail := make([]int32, len(m.outputs))
+	queue := make([]int32, 0, len(m.outputs))
+	for c := 0; c < k; c++ {
+		if s := m.next[c]; s == -1 {
+			m.next[c] = 0
+		} else {
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for c := 0; c < k; c++ {
+			v := m.next[int(u)*k+c]
+			f := m.next[int(fail[u])*k+c]
+			if v == -1 {
+				m.next[int(u)*k+c] = f
+				continue
+			}
+			fail[v] = f
+			if len(m.outputs[f]) > 0 {
+				m.outputs[v] = append(m.outputs[v], m.outputs[f]...)
+			}
+			queue = append(queue, v)
+		}
+	}
+	return m
+}
+
+// newRow appends a row of missing transitions to the transition table.
+func newRow(next []int32, k int) []int32 {
+	for c := 0; c < k; c++ {
+		next = append(next, -1)
+	}
+	return next
+}
+
+// scan calls fn for every occurrence of every pattern in the text, in order of the occurrence's ending offset.
+func (m *matcher) scan(text string, fn func(pattern, end int)) {
+	for _, p := range m.empty {
+		fn(int(p), 0)
+	}
+	state := int32(0)
+	for i := 0; i < len(text); i++ {
+		state = m.next[int(state)*m.numClasses+int(m.classes[text[i]])]
+		for _, p := range m.outputs[state] {
+			fn(int(p), i+1)
+		}
+	}
+}
+
+// findAll returns every occurrence of every pattern in the text, with its starting offset.
+func (m *matcher) findAll(text string) []hit {
+	var hits []hit
+	m.scan(text, func(pattern, end int) {
+		hits = append(hits, hit{pattern: pattern, start: end - m.lengths[pattern]})
+	})
+	return hits
+}
+
+// matchSet returns a bit set of the patterns found in the text, indexed by pattern number.
+func (m *matcher) matchSet(text string) bitSet {
+	set := make(bitSet, (len(m.lengths)+63)/64)
+	m.scan(text, func(pattern, end int) {
+		set[pattern/64] |= 1 << (pattern % 64)
+	})
+	return set
+}
+
+// bitSet is a set of non-negative integers.
+type bitSet []uint64
+
+// each calls fn for each member of the set in ascending order, stopping if fn returns false.
+func (s bitSet) each(fn func(i int) bool) {
+	for w, word := range s {
+		for word != 0 {
+			b := bits.TrailingZeros64(word)
+			if !fn(w*64 + b) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package user_agent
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// naiveFindAll finds every occurrence of every pattern with strings.Index, for comparison with the automaton.
+func naiveFindAll(patterns []string, text string) []hit {
+	var hits []hit
+	for p, pattern := range patterns {
+		if pattern == "" {
+			hits = append(hits, hit{pattern: p, start: 0})
+			continue
+		}
+		for i := 0; i+len(pattern) <= len(text); i++ {
+			if strings.HasPrefix(text[i:], pattern) {
+				hits = append(hits, hit{pattern: p, start: i})
+			}
+		}
+	}
+	return hits
+}
+
+func sortHits(hits []hit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].pattern != hits[j].pattern {
+			return hits[i].pattern < hits[j].pattern
+		}
+		return hits[i].start < hits[j].start
+	})
+}
+
+func Test_matcher_findAll(t *testing.T) {
+	cases := []struct {
+		name     string
+		patterns []string
+		text     string
+	}{
+		{"no patterns", nil, "Chrome/101.0"},
+		{"empty text", []string{"Chrome"}, ""},
+		{"overlapping", []string{"he", "she", "his", "hers"}, "ushers and his sheep"},
+		{"suffixes", []string{"Edg/", "Edge/", "dge/", "e/"}, "Edge/18.19041 Edg/101.0"},
+		{"duplicates", []string{"Mobile", "Mobile", "Mob"}, "Mobile Safari Mobile/15E148"},
+		{"empty pattern", []string{"", "Linux"}, "X11 Linux"},
+		{"repeated", []string{"aa", "aaa"}, "aaaaa"},
+		{"all bytes", allBytes(), "\x00\x01 ab\xfe\xff\xff\x00"},
+		{"default finds", finds(patterns), "Linux Android 12 SM-G991B Build/SP1A.210812.016 Chrome/100.0.4896.88 Mobile Safari/537.36 OPT/2.9"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hits := newMatcher(c.patterns).findAll(c.text)
+			expected := naiveFindAll(c.patterns, c.text)
+			sortHits(hits)
+			sortHits(expected)
+			if !reflect.DeepEqual(hits, expected) {
+				t.Errorf("expected/received:\n%v\n%v", expected, hits)
+			}
+		})
+	}
+}
+
+func Test_matcher_matchSet(t *testing.T) {
+	patterns := []string{"iPhone", "Mobile", "Android", "Chrome", "Safari"}
+	var found []int
+	newMatcher(patterns).matchSet("iPhone OS 15_5 Version/15.5 Mobile/15E148 Safari/604.1").each(func(i int) bool {
+		found = append(found, i)
+		return true
+	})
+	if !reflect.DeepEqual(found, []int{0, 1, 4}) {
+		t.Errorf("expected [0 1 4], received %v", found)
+	}
+	// stop early
+	found = nil
+	newMatcher(patterns).matchSet("iPhone OS 15_5 Version/15.5 Mobile/15E148 Safari/604.1").each(func(i int) bool {
+		found = append(found, i)
+		return len(found) < 2
+	})
+	if !reflect.DeepEqual(found, []int{0, 1}) {
+		t.Errorf("expected [0 1], received %v", found)
+	}
+}
+
+// allBytes returns patterns using all 256 byte values, more than fit in a byte-sized equivalence class, with the
+// first and last byte values in a pattern together.
+func allBytes() []string {
+	patterns := []string{"\xff\x00"}
+	for b := 0; b < 256; b++ {
+		patterns = append(patterns, string([]byte{byte(b)}))
+	}
+	return patterns
+}
+
+// finds returns the Find text of the supplied pattern matchers.
+func finds(matches []Match) []string {
+	ss := make([]string, len(matches))
+	for i, m := range matches {
+		ss[i] = m.Find
+	}
+	return ss
+}
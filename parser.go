@@ -0,0 +1,186 @@
+package user_agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultParser is used by the package-level Parse functions.
+var defaultParser = NewParser()
+
+// Default returns the Parser used by the package-level Parse functions, allowing its matchers to be updated.
+func Default() *Parser {
+	return defaultParser
+}
+
+// Parser extracts UserAgent information from User-Agent request headers, using an ordered set of pattern matchers.
+// By default, it uses the built-in pattern matchers, but custom matchers may be added (e.g. for internal crawlers or
+// partner applications), or replaced entirely while the Parser is in use. A Parser is safe for concurrent use.
+type Parser struct {
+	mu    sync.Mutex   // serializes updates to the matchers
+	rules atomic.Value // *ruleSet, replaced (never modified) when the matchers are updated
+}
+
+// ruleSet is an ordered set of pattern matchers, compiled into a single automaton that finds all the matching Find
+// text in one pass over a User-Agent string.
+type ruleSet struct {
+	matches []Match
+	matcher *matcher
+}
+
+// defaultRuleSet contains the compiled built-in pattern matchers.
+var defaultRuleSet = compileRules(patterns)
+
+// compileRules compiles the supplied pattern matchers, which must not be modified afterwards.
+func compileRules(matches []Match) *ruleSet {
+	finds := make([]string, len(matches))
+	for i, m := range matches {
+		finds[i] = m.Find
+	}
+	return &ruleSet{matches: matches, matcher: newMatcher(finds)}
+}
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// WithMatches replaces the built-in pattern matchers with the supplied matchers.
+func WithMatches(matches ...Match) Option {
+	return func(p *Parser) {
+		p.rules.Store(compileRules(append([]Match(nil), matches...)))
+	}
+}
+
+// NewParser creates a new Parser with the built-in pattern matchers, configured with the supplied options.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	p.rules.Store(defaultRuleSet)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Matches returns a copy of the pattern matchers used by the Parser, in order.
+func (p *Parser) Matches() []Match {
+	return append([]Match(nil), p.load().matches...)
+}
+
+// Prepend adds pattern matchers to the beginning of the Parser's matchers, giving them precedence over the
+// existing matchers.
+func (p *Parser) Prepend(matches ...Match) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current := p.load().matches
+	ms := make([]Match, 0, len(matches)+len(current))
+	ms = append(ms, matches...)
+	p.rules.Store(compileRules(append(ms, current...)))
+}
+
+// Append adds pattern matchers to the end of the Parser's matchers, to be used if none of the existing matchers
+// apply.
+func (p *Parser) Append(matches ...Match) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	current := p.load().matches
+	ms := make([]Match, 0, len(current)+len(matches))
+	ms = append(ms, current...)
+	p.rules.Store(compileRules(append(ms, matches...)))
+}
+
+// Parse extracts client, device, and operating system information from the User-Agent request header provided,
+// returning a UserAgent. Note that the URL and versions will be empty if not provided. Other fields, however,
+// will be set to "Other" if the relevant information is not provided, or if the determination is inconclusive.
+func (p *Parser) Parse(userAgent string) UserAgent {
+	return parse(userAgent, p.load())
+}
+
+// ParseWithHints extracts client, device, and operating system information from the User-Agent request header
+// provided, using the supplied Client Hints to fill in or override the client name and version, the operating
+// system name and version, and the device type.
+func (p *Parser) ParseWithHints(userAgent string, hints ClientHints) UserAgent {
+	ua := p.Parse(userAgent)
+	applyHints(&ua, hints)
+	return ua
+}
+
+// ParseHeaders extracts client, device, and operating system information from the User-Agent request header and
+// any User-Agent Client Hints headers provided, returning a UserAgent.
+func (p *Parser) ParseHeaders(h http.Header) UserAgent {
+	return p.ParseWithHints(h.Get("User-Agent"), ParseClientHints(h))
+}
+
+// Reload replaces all the Parser's pattern matchers (including any prepended or appended matchers) with the
+// matchers read from the supplied Reader, in the format of the built-in rules.json file. If the matchers can't be
+// loaded, the Parser keeps its current matchers. Parse calls already in progress continue to use the old matchers.
+func (p *Parser) Reload(r io.Reader) error {
+	matches, err := LoadMatches(r)
+	if err != nil {
+		return err
+	}
+	rules := compileRules(matches)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules.Store(rules)
+	return nil
+}
+
+// ReloadFile replaces all the Parser's pattern matchers with the matchers read from the file at the supplied path.
+func (p *Parser) ReloadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return p.Reload(f)
+}
+
+// WatchFile loads the Parser's pattern matchers from the file at the supplied path, and then polls the file at the
+// supplied interval, reloading the matchers whenever the file's size or modification time changes. Polling stops
+// when the context is done. An error is returned if the interval isn't positive, or if the file can't be loaded
+// initially. Subsequent errors are reported to onError (if provided), and the Parser keeps its current matchers.
+func (p *Parser) WatchFile(ctx context.Context, path string, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid watch interval %v", interval)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err = p.ReloadFile(path); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				latest, err := os.Stat(path)
+				if err == nil && latest.Size() == info.Size() && latest.ModTime().Equal(info.ModTime()) {
+					continue
+				}
+				if err == nil {
+					info = latest
+					err = p.ReloadFile(path)
+				}
+				if err != nil && onError != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// load returns the current compiled pattern matchers, which must not be modified.
+func (p *Parser) load() *ruleSet {
+	return p.rules.Load().(*ruleSet)
+}
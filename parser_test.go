@@ -0,0 +1,179 @@
+package user_agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewParser(t *testing.T) {
+	t.Run("default matches", func(t *testing.T) {
+		p := NewParser()
+		if len(p.Matches()) != len(patterns) {
+			t.Errorf("expected %d matches, received %d", len(patterns), len(p.Matches()))
+		}
+		ua := "Mozilla/5.0 (Windows NT 10.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36"
+		if s := p.Parse(ua).String(); s != Parse(ua).String() {
+			t.Errorf("expected/received:\n%s\n%s", Parse(ua).String(), s)
+		}
+	})
+	t.Run("custom matches", func(t *testing.T) {
+		p := NewParser(WithMatches(
+			Match{Find: "Android", DeviceType: "Mobile", OSName: "Android"},
+			Match{Find: "AcmeApp", ClientType: "App", ClientName: "Acme"},
+		))
+		s := p.Parse("Mozilla/5.0 (Linux; Android 12) AcmeApp/3.2.1").String()
+		expected := "App Acme 3.2 Mobile Android 12"
+		if s != expected {
+			t.Errorf("expected/received:\n%s\n%s", expected, s)
+		}
+	})
+}
+
+func TestParser_Prepend(t *testing.T) {
+	p := NewParser()
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.64 Safari/537.36 AcmeCrawler/1.2"
+	before := p.Parse(ua)
+	p.Prepend(Match{Find: "AcmeCrawler", ClientType: "Bot", ClientName: "AcmeCrawler"})
+	after := p.Parse(ua)
+	if before.String() != "Bot AcmeCrawler 1.2 Desktop Windows 10.0" || !before.Heuristic {
+		t.Errorf("unexpected result before Prepend: %s (heuristic %t)", before, before.Heuristic)
+	}
+	if after.String() != "Bot AcmeCrawler 1.2 Desktop Windows 10.0" || after.Heuristic {
+		t.Errorf("unexpected result after Prepend: %s (heuristic %t)", after, after.Heuristic)
+	}
+	if s := Parse(ua); s.String() != before.String() || !s.Heuristic {
+		t.Errorf("Prepend modified the default parser: %s (heuristic %t)", s, s.Heuristic)
+	}
+}
+
+func TestParser_Append(t *testing.T) {
+	p := NewParser()
+	ua := "AcmeMonitor/2.0 (Linux)"
+	p.Append(
+		Match{Find: "AcmeMonitor", ClientType: "Bot", ClientName: "AcmeMonitor"},
+		Match{Find: "Linux", DeviceType: "Mobile"}, // shadowed by the built-in Linux matcher for OSName only
+	)
+	s := p.Parse(ua).String()
+	expected := "Bot AcmeMonitor 2.0 Mobile Linux"
+	if s != expected {
+		t.Errorf("expected/received:\n%s\n%s", expected, s)
+	}
+	ms := p.Matches()
+	if last := ms[len(ms)-1]; last.Find != "Linux" || last.DeviceType != "Mobile" {
+		t.Errorf("unexpected last match: %+v", last)
+	}
+}
+
+// TestParser_concurrency checks that a Parser can be used concurrently while matchers are added.
+// Run with the -race flag to detect data races.
+func TestParser_concurrency(t *testing.T) {
+	p := NewParser()
+	ua := "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.64 Safari/537.36"
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if s := p.Parse(ua).String(); s != "Browser Chrome 101.0 Desktop Linux" {
+					t.Errorf("unexpected result: %s", s)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			p.Append(Match{Find: "AcmeBrowser", ClientType: "Browser", ClientName: "Acme"})
+		}()
+	}
+	wg.Wait()
+	if n := len(p.Matches()); n != len(patterns)+4 {
+		t.Errorf("expected %d matches, received %d", len(patterns)+4, n)
+	}
+}
+
+func TestParser_Reload(t *testing.T) {
+	p := NewParser()
+	ua := "Mozilla/5.0 (Linux; Android 12) AcmeApp/3.2.1"
+	err := p.Reload(strings.NewReader(`[{"find": "AcmeApp", "clientType": "App", "clientName": "Acme"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "App Acme 3.2 Desktop Other"
+	if s := p.Parse(ua).String(); s != expected {
+		t.Errorf("expected/received:\n%s\n%s", expected, s)
+	}
+	// invalid rules are rejected, keeping the current matchers
+	err = p.Reload(strings.NewReader(`[{"find": "AcmeApp", "clientType": "Application"}]`))
+	if err == nil {
+		t.Errorf("expected an error for invalid rules")
+	}
+	if s := p.Parse(ua).String(); s != expected {
+		t.Errorf("expected/received:\n%s\n%s", expected, s)
+	}
+}
+
+func TestParser_WatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	write := func(rules string, modTime time.Time) {
+		if err := os.WriteFile(path, []byte(rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now().Add(-time.Hour)
+	write(`[{"find": "AcmeBot", "clientType": "Bot", "clientName": "AcmeBot"}]`, start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 10)
+	p := NewParser()
+	if err := p.WatchFile(ctx, path, time.Millisecond, func(err error) { errs <- err }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ua := "AcmeBot/1.0 AcmeCrawler/2.0"
+	if s := p.Parse(ua).String(); s != "Bot AcmeBot 1.0 Desktop Other" {
+		t.Errorf("unexpected result after initial load: %s", s)
+	}
+
+	// an updated file is reloaded
+	write(`[{"find": "AcmeCrawler", "clientType": "Bot", "clientName": "AcmeCrawler"}]`, start.Add(time.Minute))
+	deadline := time.Now().Add(5 * time.Second)
+	for p.Parse(ua).ClientName != "AcmeCrawler" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if s := p.Parse(ua).String(); s != "Bot AcmeCrawler 2.0 Desktop Other" {
+		t.Errorf("unexpected result after update: %s", s)
+	}
+
+	// an invalid file is reported, keeping the current matchers
+	write(`[{"find": ""}]`, start.Add(2*time.Minute))
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Errorf("expected an error for invalid rules")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for an error")
+	}
+	if s := p.Parse(ua).String(); s != "Bot AcmeCrawler 2.0 Desktop Other" {
+		t.Errorf("unexpected result after invalid update: %s", s)
+	}
+
+	// a missing file can't be watched
+	if err := p.WatchFile(ctx, path+".missing", time.Millisecond, nil); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+
+	// the polling interval must be positive
+	if err := p.WatchFile(ctx, path, 0, nil); err == nil {
+		t.Errorf("expected an error for a zero interval")
+	}
+}
@@ -0,0 +1,139 @@
+package user_agent
+
+import "strings"
+
+// Product is a product token in a User-Agent request header, with the comments that follow it. For example,
+// "Mozilla/5.0 (Windows NT 10.0; Win64; x64)" is the product "Mozilla", version "5.0", with the comment
+// "Windows NT 10.0; Win64; x64".
+type Product struct {
+	// Name is the product name (e.g. Chrome), which is empty for comments preceding any product
+	Name string `json:"name,omitempty"`
+
+	// Version is the product version (e.g. 101.0.4951.67), if provided
+	Version string `json:"version,omitempty"`
+
+	// Comments contains the text of the comments following the product, without the enclosing parentheses.
+	// Nested comments are included with their parentheses, and quoted-pair escapes are removed.
+	Comments []string `json:"comments,omitempty"`
+}
+
+// String supports the Stringer interface, providing the product in User-Agent syntax.
+func (p Product) String() string {
+	s := p.Name
+	if p.Version != "" {
+		s = s + "/" + p.Version
+	}
+	for _, c := range p.Comments {
+		if s != "" {
+			s = s + " "
+		}
+		s = s + "(" + c + ")"
+	}
+	return s
+}
+
+// ParseProducts parses a User-Agent request header into an ordered list of products and their comments, following
+// the User-Agent grammar in RFC 9110 (https://www.rfc-editor.org/rfc/rfc9110#name-user-agent):
+//
+//	User-Agent = product *( RWS ( product / comment ) )
+//	product    = token [ "/" product-version ]
+//	comment    = "(" *( ctext / quoted-pair / comment ) ")"
+//
+// The parser is lenient, because real-world User-Agent strings often violate the grammar: any run of characters
+// other than whitespace and parentheses is treated as a product (URLs are not split into a name and version), and
+// an unterminated comment extends to the end of the header.
+func ParseProducts(header string) []Product {
+	if header == "" {
+		return nil
+	}
+	// The products and comments are allocated up front, with enough capacity for the header, and the comments of each
+	// product share the memory of a single slice, so that parsing makes few allocations.
+	products := make([]Product, 0, strings.Count(header, " ")+1)
+	comments := make([]string, 0, strings.Count(header, "("))
+	for i := 0; i < len(header); {
+		switch c := header[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			comment, n := parseComment(header[i:])
+			i += n
+			if len(products) == 0 {
+				products = append(products, Product{})
+			}
+			p := &products[len(products)-1]
+			comments = append(comments, comment)
+			end := len(comments)
+			p.Comments = comments[end-len(p.Comments)-1 : end : end]
+		case c == ')':
+			i++ // unbalanced
+		default:
+			start := i
+			for i < len(header) && !isProductDelimiter(header[i]) {
+				i++
+			}
+			token := header[start:i]
+			if strings.Contains(token, "://") {
+				products = append(products, Product{Name: token}) // URL
+				continue
+			}
+			name, version, _ := strings.Cut(token, "/")
+			products = append(products, Product{Name: name, Version: version})
+		}
+	}
+	return products
+}
+
+// isProductDelimiter returns true for the characters that terminate a product token.
+func isProductDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '(' || c == ')'
+}
+
+// parseComment parses the comment at the beginning of the supplied text, returning the comment text (without the
+// enclosing parentheses) and the number of bytes consumed. Nested comments are retained with their parentheses.
+// The comment text shares the memory of the supplied text, unless it contains quoted-pair escapes.
+func parseComment(s string) (string, int) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			return unescapeComment(s)
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(s[1:i]), i + 1
+			}
+		}
+	}
+	return strings.TrimSpace(s[1:]), len(s) // unterminated
+}
+
+// unescapeComment parses a comment containing quoted-pair escapes, like parseComment, removing the escapes.
+func unescapeComment(s string) (string, int) {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '(':
+			if depth > 0 {
+				b.WriteByte(c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(b.String()), i + 1
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return strings.TrimSpace(b.String()), len(s) // unterminated
+}
@@ -0,0 +1,107 @@
+package user_agent
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProducts(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected []Product
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "single product",
+			input:    "curl/7.64.1",
+			expected: []Product{{Name: "curl", Version: "7.64.1"}},
+		},
+		{
+			name:  "browser",
+			input: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36",
+			expected: []Product{
+				{Name: "Mozilla", Version: "5.0", Comments: []string{"Windows NT 10.0; Win64; x64"}},
+				{Name: "AppleWebKit", Version: "537.36", Comments: []string{"KHTML, like Gecko"}},
+				{Name: "Chrome", Version: "101.0.4951.67"},
+				{Name: "Safari", Version: "537.36"},
+			},
+		},
+		{
+			name:  "nested parentheses",
+			input: "Mozilla/5.0 (Linux; Android 10; moto e (XT2052DL)) Chrome/98.0.4758.101",
+			expected: []Product{
+				{Name: "Mozilla", Version: "5.0", Comments: []string{"Linux; Android 10; moto e (XT2052DL)"}},
+				{Name: "Chrome", Version: "98.0.4758.101"},
+			},
+		},
+		{
+			name:  "multiple comments",
+			input: "Mozilla/5.00 (Nikto/2.1.6) (Evasions:None) (Test:Port Check)",
+			expected: []Product{
+				{Name: "Mozilla", Version: "5.00", Comments: []string{"Nikto/2.1.6", "Evasions:None", "Test:Port Check"}},
+			},
+		},
+		{
+			name:  "quoted pair",
+			input: `Acme/1.0 (escaped \) and \\ characters)`,
+			expected: []Product{
+				{Name: "Acme", Version: "1.0", Comments: []string{`escaped ) and \ characters`}},
+			},
+		},
+		{
+			name:  "leading comment",
+			input: "(compatible; Acme) Acme/2",
+			expected: []Product{
+				{Comments: []string{"compatible; Acme"}},
+				{Name: "Acme", Version: "2"},
+			},
+		},
+		{
+			name:  "unbalanced parentheses",
+			input: "Mozilla/5.0 (compatible; Seekport Crawler; http://seekport.com/",
+			expected: []Product{
+				{Name: "Mozilla", Version: "5.0", Comments: []string{"compatible; Seekport Crawler; http://seekport.com/"}},
+			},
+		},
+		{
+			name:  "no versions",
+			input: "SiteScoreBot v20210315 - https://sitescore.ai)",
+			expected: []Product{
+				{Name: "SiteScoreBot"},
+				{Name: "v20210315"},
+				{Name: "-"},
+				{Name: "https://sitescore.ai"},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			products := ParseProducts(c.input)
+			if !reflect.DeepEqual(products, c.expected) {
+				t.Errorf("expected/received:\n%+v\n%+v", c.expected, products)
+			}
+		})
+	}
+}
+
+func TestProduct_String(t *testing.T) {
+	cases := []struct {
+		product  Product
+		expected string
+	}{
+		{Product{Name: "curl", Version: "7.64.1"}, "curl/7.64.1"},
+		{Product{Name: "Mozilla", Version: "5.0", Comments: []string{"X11; Linux x86_64"}}, "Mozilla/5.0 (X11; Linux x86_64)"},
+		{Product{Comments: []string{"compatible", "Acme"}}, "(compatible) (Acme)"},
+		{Product{Name: "Safari"}, "Safari"},
+	}
+	for _, c := range cases {
+		if s := c.product.String(); s != c.expected {
+			t.Errorf("expected/received:\n%s\n%s", c.expected, s)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package user_agent
+
+// osRelease is a named operating system release, which applies to the versions from its Version up to (but not
+// including) the next release with the same major version number.
+type osRelease struct {
+	Version  string // first version of the release (e.g. 6.1 or 4.0.3)
+	Name     string // release name (e.g. 7, Ventura, or Tiramisu)
+	APILevel int    // Android API level, if applicable
+}
+
+// osReleases lists the named releases of each operating system, in ascending version order. Windows versions are NT
+// kernel versions, so Windows 11 (NT 10.0) is only named when the Sec-CH-UA-Platform-Version Client Hint indicates
+// it (see platformVersion). Android releases since 10 have no public dessert name, so their internal codenames are
+// used instead.
+var osReleases = map[OSName][]osRelease{
+	OSNameWindows: {
+		{"5.0", "2000", 0},
+		{"5.1", "XP", 0},
+		{"5.2", "XP", 0}, // XP Professional x64 Edition
+		{"6.0", "Vista", 0},
+		{"6.1", "7", 0},
+		{"6.2", "8", 0},
+		{"6.3", "8.1", 0},
+		{"10.0", "10", 0},
+	},
+	OSNameMacOS: {
+		{"10.0", "Cheetah", 0},
+		{"10.1", "Puma", 0},
+		{"10.2", "Jaguar", 0},
+		{"10.3", "Panther", 0},
+		{"10.4", "Tiger", 0},
+		{"10.5", "Leopard", 0},
+		{"10.6", "Snow Leopard", 0},
+		{"10.7", "Lion", 0},
+		{"10.8", "Mountain Lion", 0},
+		{"10.9", "Mavericks", 0},
+		{"10.10", "Yosemite", 0},
+		{"10.11", "El Capitan", 0},
+		{"10.12", "Sierra", 0},
+		{"10.13", "High Sierra", 0},
+		{"10.14", "Mojave", 0},
+		{"10.15", "Catalina", 0},
+		{"11", "Big Sur", 0},
+		{"12", "Monterey", 0},
+		{"13", "Ventura", 0},
+		{"14", "Sonoma", 0},
+		{"15", "Sequoia", 0},
+		{"26", "Tahoe", 0},
+	},
+	OSNameAndroid: {
+		{"1.0", "", 1},
+		{"1.1", "", 2},
+		{"1.5", "Cupcake", 3},
+		{"1.6", "Donut", 4},
+		{"2.0", "Eclair", 5},
+		{"2.0.1", "Eclair", 6},
+		{"2.1", "Eclair", 7},
+		{"2.2", "Froyo", 8},
+		{"2.3", "Gingerbread", 9},
+		{"2.3.3", "Gingerbread", 10},
+		{"3.0", "Honeycomb", 11},
+		{"3.1", "Honeycomb", 12},
+		{"3.2", "Honeycomb", 13},
+		{"4.0", "Ice Cream Sandwich", 14},
+		{"4.0.3", "Ice Cream Sandwich", 15},
+		{"4.1", "Jelly Bean", 16},
+		{"4.2", "Jelly Bean", 17},
+		{"4.3", "Jelly Bean", 18},
+		{"4.4", "KitKat", 19},
+		{"5.0", "Lollipop", 21},
+		{"5.1", "Lollipop", 22},
+		{"6.0", "Marshmallow", 23},
+		{"7.0", "Nougat", 24},
+		{"7.1", "Nougat", 25},
+		{"8.0", "Oreo", 26},
+		{"8.1", "Oreo", 27},
+		{"9", "Pie", 28},
+		{"10", "Quince Tart", 29},
+		{"11", "Red Velvet Cake", 30},
+		{"12", "Snow Cone", 31},
+		{"12.1", "Snow Cone", 32}, // 12L
+		{"13", "Tiramisu", 33},
+		{"14", "Upside Down Cake", 34},
+		{"15", "Vanilla Ice Cream", 35},
+		{"16", "Baklava", 36},
+	},
+}
+
+// lookupRelease returns the named release of the operating system version, if it's known.
+func lookupRelease(osName OSName, ver Version) (osRelease, bool) {
+	var found osRelease
+	ok := false
+	for _, r := range osReleases[osName] {
+		v := ParseVersion(r.Version)
+		if v.Major == ver.Major && v.Compare(ver) <= 0 {
+			found, ok = r, true
+		}
+	}
+	return found, ok
+}
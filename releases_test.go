@@ -0,0 +1,43 @@
+package user_agent
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOSVersionNames(t *testing.T) {
+	cases := []struct {
+		name     string
+		ua       string
+		hint     string
+		release  string
+		apiLevel int
+	}{
+		{"WindowsXP", "Mozilla/4.0 (compatible; MSIE 8.0; Windows NT 5.1; Trident/4.0)", "", "XP", 0},
+		{"Windows7", "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko", "", "7", 0},
+		{"Windows8.1", "Mozilla/5.0 (Windows NT 6.3; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36", "", "8.1", 0},
+		{"Windows10", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36", `"10.0.0"`, "10", 0},
+		{"Windows11", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36", `"15.0.0"`, "11", 0},
+		{"Catalina", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.4 Safari/605.1.15", "", "Catalina", 0},
+		{"Ventura", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Safari/537.36", `"13.2.1"`, "Ventura", 0},
+		{"Tahoe", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/140.0.0.0 Safari/537.36", `"26.0.1"`, "Tahoe", 0},
+		{"IceCreamSandwich", "Mozilla/5.0 (Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K) AppleWebkit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30", "", "Ice Cream Sandwich", 15},
+		{"Pie", "Mozilla/5.0 (Linux; Android 9; SM-G960U) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.61 Mobile Safari/537.36", "", "Pie", 28},
+		{"Tiramisu", "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.0.0 Mobile Safari/537.36", "", "Tiramisu", 33},
+		{"iOS", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1", "", "", 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("User-Agent", c.ua)
+			if c.hint != "" {
+				h.Set("Sec-CH-UA-Platform", `"`+string(Parse(c.ua).OSName)+`"`)
+				h.Set("Sec-CH-UA-Platform-Version", c.hint)
+			}
+			ua := ParseHeaders(h)
+			if ua.OSVersionName != c.release || ua.OSAPILevel != c.apiLevel {
+				t.Errorf("expected/received:\n%q %d\n%q %d", c.release, c.apiLevel, ua.OSVersionName, ua.OSAPILevel)
+			}
+		})
+	}
+}
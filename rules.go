@@ -0,0 +1,135 @@
+package user_agent
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// defaultRules contains the built-in pattern matchers, in order. Each entry is a JSON object with the fields of a
+// Match (find, deviceType, osName, clientType, clientName, and an optional note).
+//
+//go:embed rules.json
+var defaultRules []byte
+
+// patterns are used to identify appropriate fields in the UserAgent struct.
+var patterns = mustLoadMatches(defaultRules)
+
+// ValidationError lists the problems found in a set of pattern matchers.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error supports the error interface, listing all the problems found.
+func (e *ValidationError) Error() string {
+	return "invalid matches: " + strings.Join(e.Problems, "; ")
+}
+
+// LoadMatches reads an ordered JSON array of pattern matchers (in the format of the built-in rules.json file) from
+// the supplied Reader, returning an error if the JSON is malformed or the matchers are invalid.
+func LoadMatches(r io.Reader) ([]Match, error) {
+	// Decode the field values as plain strings, so that unknown values are reported by ValidateMatches
+	var raw []struct {
+		Find       string `json:"find"`
+		DeviceType string `json:"deviceType"`
+		OSName     string `json:"osName"`
+		ClientType string `json:"clientType"`
+		ClientName string `json:"clientName"`
+		Note       string `json:"note"`
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding matches: %w", err)
+	}
+	matches := make([]Match, len(raw))
+	for i, m := range raw {
+		matches[i] = Match{
+			Find:       m.Find,
+			DeviceType: DeviceType(m.DeviceType),
+			OSName:     OSName(m.OSName),
+			ClientType: ClientType(m.ClientType),
+			ClientName: m.ClientName,
+			Note:       m.Note,
+		}
+	}
+	if err := ValidateMatches(matches); err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// LoadMatchesFile reads an ordered JSON array of pattern matchers from the file at the supplied path.
+func LoadMatchesFile(path string) ([]Match, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadMatches(f)
+}
+
+// ValidateMatches checks a set of pattern matchers for problems, returning a *ValidationError if any are found.
+// Each Match must have Find text and at least one valid field value, the Find text must be unique, and the Match
+// must not be shadowed by an earlier Match (one whose Find text it contains, providing all the same fields).
+func ValidateMatches(matches []Match) error {
+	var problems []string
+	report := func(i int, m Match, format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("match %d (%q): ", i, m.Find)+fmt.Sprintf(format, args...))
+	}
+	seen := make(map[string]int, len(matches))
+	for i, m := range matches {
+		if m.Find == "" {
+			report(i, m, "missing find text")
+		}
+		if m.DeviceType == "" && m.OSName == "" && m.ClientType == "" && m.ClientName == "" {
+			report(i, m, "no fields provided")
+		}
+		if m.DeviceType != "" && !m.DeviceType.IsValid() {
+			report(i, m, "unknown deviceType %q", m.DeviceType)
+		}
+		if m.OSName != "" && !m.OSName.IsValid() {
+			report(i, m, "unknown osName %q", m.OSName)
+		}
+		if m.ClientType != "" && !m.ClientType.IsValid() {
+			report(i, m, "unknown clientType %q", m.ClientType)
+		}
+		if j, ok := seen[m.Find]; ok {
+			report(i, m, "duplicate of match %d", j)
+		} else if m.Find != "" {
+			seen[m.Find] = i
+			for j, earlier := range matches[:i] {
+				if earlier.Find != "" && strings.Contains(m.Find, earlier.Find) && shadows(earlier, m) {
+					report(i, m, "shadowed by match %d (%q)", j, earlier.Find)
+					break
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// shadows returns true if the earlier Match provides every field provided by the later Match, meaning that the
+// later Match can never have any effect when its Find text contains the earlier Match's Find text.
+func shadows(earlier, later Match) bool {
+	return (later.DeviceType == "" || earlier.DeviceType != "") &&
+		(later.OSName == "" || earlier.OSName != "") &&
+		(later.ClientType == "" || earlier.ClientType != "") &&
+		(later.ClientName == "" || earlier.ClientName != "")
+}
+
+// mustLoadMatches loads the built-in pattern matchers, panicking if they're invalid.
+func mustLoadMatches(data []byte) []Match {
+	matches, err := LoadMatches(bytes.NewReader(data))
+	if err != nil {
+		panic("user_agent: built-in rules.json: " + err.Error())
+	}
+	return matches
+}
@@ -0,0 +1,113 @@
+package user_agent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadMatches(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected []Match
+		problems []string
+	}{
+		{
+			name:     "valid",
+			input:    `[{"find": "AcmeBot", "clientType": "Bot", "clientName": "AcmeBot", "note": "internal crawler"}]`,
+			expected: []Match{{Find: "AcmeBot", ClientType: "Bot", ClientName: "AcmeBot", Note: "internal crawler"}},
+		},
+		{
+			name:     "missing fields",
+			input:    `[{"find": ""}]`,
+			problems: []string{`match 0 (""): missing find text`, `match 0 (""): no fields provided`},
+		},
+		{
+			name:  "unknown values",
+			input: `[{"find": "Acme", "deviceType": "Phone", "osName": "AcmeOS", "clientType": "Robot"}]`,
+			problems: []string{
+				`match 0 ("Acme"): unknown deviceType "Phone"`,
+				`match 0 ("Acme"): unknown osName "AcmeOS"`,
+				`match 0 ("Acme"): unknown clientType "Robot"`,
+			},
+		},
+		{
+			name:     "duplicate",
+			input:    `[{"find": "Acme", "clientName": "Acme"}, {"find": "Acme", "clientType": "App"}]`,
+			problems: []string{`match 1 ("Acme"): duplicate of match 0`},
+		},
+		{
+			name:     "shadowed",
+			input:    `[{"find": "Acme", "clientType": "App", "clientName": "Acme"}, {"find": "AcmeBot", "clientType": "Bot", "clientName": "AcmeBot"}]`,
+			problems: []string{`match 1 ("AcmeBot"): shadowed by match 0 ("Acme")`},
+		},
+		{
+			name:     "partially shadowed",
+			input:    `[{"find": "Acme", "clientName": "Acme"}, {"find": "AcmeMobile", "deviceType": "Mobile", "clientName": "AcmeMobile"}]`,
+			expected: []Match{{Find: "Acme", ClientName: "Acme"}, {Find: "AcmeMobile", DeviceType: "Mobile", ClientName: "AcmeMobile"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			matches, err := LoadMatches(strings.NewReader(c.input))
+			if c.problems == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(matches, c.expected) {
+					t.Errorf("expected/received:\n%+v\n%+v", c.expected, matches)
+				}
+				return
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected a ValidationError, received %v", err)
+			}
+			if !reflect.DeepEqual(ve.Problems, c.problems) {
+				t.Errorf("expected/received:\n%q\n%q", c.problems, ve.Problems)
+			}
+		})
+	}
+}
+
+func TestLoadMatches_malformed(t *testing.T) {
+	inputs := []string{
+		``,
+		`{"find": "Acme"}`,
+		`[{"find": "Acme", "clientName": "Acme", "version": "1.0"}]`,
+		`[{"find": "Acme", "clientName": "Acme"`,
+	}
+	for _, input := range inputs {
+		if _, err := LoadMatches(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+func TestLoadMatchesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, defaultRules, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := LoadMatchesFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(matches, patterns) {
+		t.Errorf("expected %d matches, received %d", len(patterns), len(matches))
+	}
+	if _, err = LoadMatchesFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+// TestValidateMatches checks that the built-in rules are valid.
+func TestValidateMatches(t *testing.T) {
+	if err := ValidateMatches(patterns); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,323 @@
+package user_agent
+
+import "fmt"
+
+// ClientType indicates the application category (App, Bot, Browser, Library, or Other).
+type ClientType string
+
+const (
+	ClientTypeApp     ClientType = "App"
+	ClientTypeBot     ClientType = "Bot"
+	ClientTypeBrowser ClientType = "Browser"
+	ClientTypeLibrary ClientType = "Library"
+	ClientTypeOther   ClientType = "Other"
+)
+
+// ClientTypes returns all the valid ClientType values.
+func ClientTypes() []ClientType {
+	return []ClientType{ClientTypeApp, ClientTypeBot, ClientTypeBrowser, ClientTypeLibrary, ClientTypeOther}
+}
+
+// IsValid returns true if the ClientType is one of the defined values.
+func (t ClientType) IsValid() bool {
+	for _, v := range ClientTypes() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String supports the Stringer interface.
+func (t ClientType) String() string {
+	return string(t)
+}
+
+// MarshalText supports the encoding.TextMarshaler interface.
+func (t ClientType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText supports the encoding.TextUnmarshaler interface, rejecting values that aren't defined.
+// An empty value is permitted, indicating that the ClientType is unknown.
+func (t *ClientType) UnmarshalText(text []byte) error {
+	v := ClientType(text)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("unknown client type %q", text)
+	}
+	*t = v
+	return nil
+}
+
+// DeviceType indicates the general device category (Desktop, Mobile, Tablet, TV, or Other).
+type DeviceType string
+
+const (
+	DeviceTypeDesktop DeviceType = "Desktop"
+	DeviceTypeMobile  DeviceType = "Mobile"
+	DeviceTypeTablet  DeviceType = "Tablet"
+	DeviceTypeTV      DeviceType = "TV"
+	DeviceTypeOther   DeviceType = "Other"
+)
+
+// DeviceTypes returns all the valid DeviceType values.
+func DeviceTypes() []DeviceType {
+	return []DeviceType{DeviceTypeDesktop, DeviceTypeMobile, DeviceTypeTablet, DeviceTypeTV, DeviceTypeOther}
+}
+
+// IsValid returns true if the DeviceType is one of the defined values.
+func (t DeviceType) IsValid() bool {
+	for _, v := range DeviceTypes() {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String supports the Stringer interface.
+func (t DeviceType) String() string {
+	return string(t)
+}
+
+// MarshalText supports the encoding.TextMarshaler interface.
+func (t DeviceType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText supports the encoding.TextUnmarshaler interface, rejecting values that aren't defined.
+// An empty value is permitted, indicating that the DeviceType is unknown.
+func (t *DeviceType) UnmarshalText(text []byte) error {
+	v := DeviceType(text)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("unknown device type %q", text)
+	}
+	*t = v
+	return nil
+}
+
+// OSName indicates the operating system running on the device (Android, Linux, iOS, macOS, Windows, etc.).
+type OSName string
+
+const (
+	OSNameAndroid  OSName = "Android"
+	OSNameChromeOS OSName = "ChromeOS"
+	OSNameIOS      OSName = "iOS"
+	OSNameIPadOS   OSName = "iPadOS"
+	OSNameLinux    OSName = "Linux"
+	OSNameMacOS    OSName = "macOS"
+	OSNameRoku     OSName = "Roku"
+	OSNameTizen    OSName = "Tizen"
+	OSNameTvOS     OSName = "tvOS"
+	OSNameWebOS    OSName = "webOS"
+	OSNameWindows  OSName = "Windows"
+	OSNameOther    OSName = "Other"
+)
+
+// OSNames returns all the valid OSName values.
+func OSNames() []OSName {
+	return []OSName{
+		OSNameAndroid, OSNameChromeOS, OSNameIOS, OSNameIPadOS, OSNameLinux, OSNameMacOS, OSNameRoku, OSNameTizen,
+		OSNameTvOS, OSNameWebOS, OSNameWindows, OSNameOther,
+	}
+}
+
+// IsValid returns true if the OSName is one of the defined values.
+func (n OSName) IsValid() bool {
+	for _, v := range OSNames() {
+		if n == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsApple returns true if the operating system is one of Apple's (iOS, iPadOS, macOS, or tvOS).
+func (n OSName) IsApple() bool {
+	return n == OSNameIOS || n == OSNameIPadOS || n == OSNameMacOS || n == OSNameTvOS
+}
+
+// String supports the Stringer interface.
+func (n OSName) String() string {
+	return string(n)
+}
+
+// MarshalText supports the encoding.TextMarshaler interface.
+func (n OSName) MarshalText() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// UnmarshalText supports the encoding.TextUnmarshaler interface, rejecting values that aren't defined.
+// An empty value is permitted, indicating that the OSName is unknown.
+func (n *OSName) UnmarshalText(text []byte) error {
+	v := OSName(text)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("unknown OS name %q", text)
+	}
+	*n = v
+	return nil
+}
+
+// BotCategory indicates the purpose of a bot (Search, SEO, Preview, AI, etc.).
+type BotCategory string
+
+const (
+	BotCategoryAccessibility BotCategory = "Accessibility"
+	BotCategoryAdvertising   BotCategory = "Advertising"
+	BotCategoryAI            BotCategory = "AI"
+	BotCategoryAutomation    BotCategory = "Automation"
+	BotCategoryMonitoring    BotCategory = "Monitoring"
+	BotCategoryPreview       BotCategory = "Preview"
+	BotCategoryScanner       BotCategory = "Scanner"
+	BotCategorySearch        BotCategory = "Search"
+	BotCategorySEO           BotCategory = "SEO"
+	BotCategoryOther         BotCategory = "Other"
+)
+
+// BotCategories returns all the valid BotCategory values.
+func BotCategories() []BotCategory {
+	return []BotCategory{
+		BotCategoryAccessibility, BotCategoryAdvertising, BotCategoryAI, BotCategoryAutomation, BotCategoryMonitoring,
+		BotCategoryPreview, BotCategoryScanner, BotCategorySearch, BotCategorySEO, BotCategoryOther,
+	}
+}
+
+// IsValid returns true if the BotCategory is one of the defined values.
+func (c BotCategory) IsValid() bool {
+	for _, v := range BotCategories() {
+		if c == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String supports the Stringer interface.
+func (c BotCategory) String() string {
+	return string(c)
+}
+
+// MarshalText supports the encoding.TextMarshaler interface.
+func (c BotCategory) MarshalText() ([]byte, error) {
+	return []byte(c), nil
+}
+
+// UnmarshalText supports the encoding.TextUnmarshaler interface, rejecting values that aren't defined.
+// An empty value is permitted, indicating that the BotCategory is unknown.
+func (c *BotCategory) UnmarshalText(text []byte) error {
+	v := BotCategory(text)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("unknown bot category %q", text)
+	}
+	*c = v
+	return nil
+}
+
+// EngineName indicates the browser rendering engine (Blink, WebKit, Gecko, Trident, EdgeHTML, or Presto).
+type EngineName string
+
+const (
+	EngineNameBlink    EngineName = "Blink"
+	EngineNameWebKit   EngineName = "WebKit"
+	EngineNameGecko    EngineName = "Gecko"
+	EngineNameTrident  EngineName = "Trident"
+	EngineNameEdgeHTML EngineName = "EdgeHTML"
+	EngineNamePresto   EngineName = "Presto"
+)
+
+// EngineNames returns all the valid EngineName values.
+func EngineNames() []EngineName {
+	return []EngineName{
+		EngineNameBlink, EngineNameWebKit, EngineNameGecko, EngineNameTrident, EngineNameEdgeHTML, EngineNamePresto,
+	}
+}
+
+// IsValid returns true if the EngineName is one of the defined values.
+func (n EngineName) IsValid() bool {
+	for _, v := range EngineNames() {
+		if n == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String supports the Stringer interface.
+func (n EngineName) String() string {
+	return string(n)
+}
+
+// MarshalText supports the encoding.TextMarshaler interface.
+func (n EngineName) MarshalText() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// UnmarshalText supports the encoding.TextUnmarshaler interface, rejecting values that aren't defined.
+// An empty value is permitted, indicating that the EngineName is unknown.
+func (n *EngineName) UnmarshalText(text []byte) error {
+	v := EngineName(text)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("unknown engine name %q", text)
+	}
+	*n = v
+	return nil
+}
+
+// Architecture indicates the CPU architecture of the device (x86, x86_64, arm, or arm64).
+type Architecture string
+
+const (
+	ArchitectureX86    Architecture = "x86"
+	ArchitectureX86_64 Architecture = "x86_64"
+	ArchitectureARM    Architecture = "arm"
+	ArchitectureARM64  Architecture = "arm64"
+)
+
+// Architectures returns all the valid Architecture values.
+func Architectures() []Architecture {
+	return []Architecture{
+		ArchitectureX86, ArchitectureX86_64, ArchitectureARM, ArchitectureARM64,
+	}
+}
+
+// IsValid returns true if the Architecture is one of the defined values.
+func (a Architecture) IsValid() bool {
+	for _, v := range Architectures() {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Bitness returns the number of bits in the Architecture's address space (32 or 64), or 0 if it's unknown.
+func (a Architecture) Bitness() int {
+	switch a {
+	case ArchitectureX86, ArchitectureARM:
+		return 32
+	case ArchitectureX86_64, ArchitectureARM64:
+		return 64
+	}
+	return 0
+}
+
+// String supports the Stringer interface.
+func (a Architecture) String() string {
+	return string(a)
+}
+
+// MarshalText supports the encoding.TextMarshaler interface.
+func (a Architecture) MarshalText() ([]byte, error) {
+	return []byte(a), nil
+}
+
+// UnmarshalText supports the encoding.TextUnmarshaler interface, rejecting values that aren't defined.
+// An empty value is permitted, indicating that the Architecture is unknown.
+func (a *Architecture) UnmarshalText(text []byte) error {
+	v := Architecture(text)
+	if v != "" && !v.IsValid() {
+		return fmt.Errorf("unknown architecture %q", text)
+	}
+	*a = v
+	return nil
+}
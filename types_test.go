@@ -0,0 +1,124 @@
+package user_agent
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestClientType(t *testing.T) {
+	for _, v := range ClientTypes() {
+		if !v.IsValid() {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []ClientType{"", "bot", "Robot"} {
+		if v.IsValid() {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestDeviceType(t *testing.T) {
+	for _, v := range DeviceTypes() {
+		if !v.IsValid() {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []DeviceType{"", "desktop", "Phone"} {
+		if v.IsValid() {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestOSName(t *testing.T) {
+	for _, v := range OSNames() {
+		if !v.IsValid() {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []OSName{"", "IOS", "MacOS", "Windows 10"} {
+		if v.IsValid() {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestBotCategory(t *testing.T) {
+	for _, v := range BotCategories() {
+		if !v.IsValid() {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []BotCategory{"", "search", "Crawler"} {
+		if v.IsValid() {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestEngineName(t *testing.T) {
+	for _, v := range EngineNames() {
+		if !v.IsValid() {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []EngineName{"", "blink", "Servo"} {
+		if v.IsValid() {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+func TestArchitecture(t *testing.T) {
+	for _, v := range Architectures() {
+		if !v.IsValid() {
+			t.Errorf("expected %q to be valid", v)
+		}
+	}
+	for _, v := range []Architecture{"", "X86", "amd64", "sparc"} {
+		if v.IsValid() {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
+
+// TestUserAgent_json checks that the typed fields are encoded as plain strings, and that unknown values are rejected.
+func TestUserAgent_json(t *testing.T) {
+	ua := Parse("Mozilla/5.0 (iPad; CPU OS 15_3_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148")
+	data, err := json.Marshal(struct {
+		ClientType ClientType `json:"clientType"`
+		DeviceType DeviceType `json:"deviceType"`
+		OSName     OSName     `json:"osName"`
+	}{ua.ClientType, ua.DeviceType, ua.OSName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"clientType":"Browser","deviceType":"Tablet","osName":"iPadOS"}`
+	if string(data) != expected {
+		t.Errorf("expected/received:\n%s\n%s", expected, data)
+	}
+
+	var decoded UserAgent
+	data, _ = json.Marshal(ua)
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != ua.String() {
+		t.Errorf("expected/received:\n%s\n%s", ua.String(), decoded.String())
+	}
+
+	invalid := []string{
+		`{"clientType":"Robot"}`,
+		`{"deviceType":"Phone"}`,
+		`{"osName":"BeOS"}`,
+		`{"architecture":"sparc"}`,
+		`{"engineName":"Servo"}`,
+		`{"botCategory":"Crawler"}`,
+	}
+	for _, s := range invalid {
+		if err = json.Unmarshal([]byte(s), &decoded); err == nil {
+			t.Errorf("expected an error for %s", s)
+		}
+	}
+}
@@ -1,80 +1,33 @@
 package user_agent
 
-import "strings"
-
-// patterns are used to identify appropriate fields in the UserAgent struct.
-var patterns = []match{
-	{find: "Macintosh", deviceType: "Desktop", operatingSystem: "macOS"},
-	{find: "iPad", deviceType: "Tablet", operatingSystem: "iPadOS"},
-	{find: "iPhone", deviceType: "Mobile", operatingSystem: "iOS"},
-	{find: "Mobile", deviceType: "Mobile"},
-	{find: "Android", deviceType: "Tablet", operatingSystem: "Android"},  // "Mobile" catches Android Mobile first
-	{find: "Windows", deviceType: "Desktop", operatingSystem: "Windows"}, // "Mobile" catches Windows Mobile first
-	{find: "CrOS", deviceType: "Desktop", operatingSystem: "ChromeOS"},
-	{find: "Tizen", operatingSystem: "Tizen"},
-	{find: "Linux", operatingSystem: "Linux"},
-	{find: "pa11y", clientType: "Bot", clientName: "Pa11y"},
-	{find: "AhrefsBot", clientType: "Bot", clientName: "AhrefsBot"},
-	{find: "Applebot", clientType: "Bot", clientName: "Applebot"},
-	{find: "Baiduspider", clientType: "Bot", clientName: "Baiduspider"},
-	{find: "adidxbot", clientType: "Bot", clientName: "AdIdxBot"},
-	{find: "bingbot", clientType: "Bot", clientName: "Bingbot"},
-	{find: "BingPreview", clientType: "Bot", clientName: "BingPreview"},
-	{find: "Cincraw", clientType: "Bot", clientName: "Cincraw"},
-	{find: "facebookexternalhit", clientType: "Bot", clientName: "FacebookBot"},
-	{find: "Googlebot", clientType: "Bot", clientName: "Googlebot"},
-	{find: "AdsBot-Google", clientType: "Bot", clientName: "Google-AdsBot"},
-	{find: "Google-Adwords", clientType: "Bot", clientName: "Google-AdWords"},
-	{find: "Google-Read-Aloud", clientType: "Bot", clientName: "Google-Read-Aloud"},
-	{find: "Google-Structured-Data-Testing-Tool", clientType: "Bot", clientName: "Google-Testing"},
-	{find: "HeadlessChrome", clientType: "Bot", clientName: "HeadlessChrome"},
-	{find: "HubSpot", clientType: "Bot", clientName: "HubSpot"},
-	{find: "Linespider", clientType: "Bot", clientName: "Linespider"},
-	{find: "PagePeeker", clientType: "Bot", clientName: "PagePeeker"},
-	{find: "Pinterestbot", clientType: "Bot", clientName: "Pinterestbot"},
-	{find: "Seekport", clientType: "Bot", clientName: "Seekport"},
-	{find: "SeoSiteCheckup", clientType: "Bot", clientName: "SeoSiteCheckup"},
-	{find: "Sitebulb", clientType: "Bot", clientName: "Sitebulb"},
-	{find: "SiteScoreBot", clientType: "Bot", clientName: "SiteScoreBot"},
-	{find: "SMTBot", clientType: "Bot", clientName: "SMTBot"},
-	{find: "Yeti", clientType: "Bot", clientName: "Yeti"},
-	{find: "YisouSpider", clientType: "Bot", clientName: "YisouSpider"},
-	{find: "FBSV", clientType: "App", clientName: "Facebook"}, // iOS
-	{find: "FBAV", clientType: "App", clientName: "Facebook"}, // Android
-	{find: "GSA/", clientType: "App", clientName: "GoogleSearch"},
-	{find: "Instagram", clientType: "App", clientName: "Instagram"},
-	{find: "LinkedInApp", clientType: "App", clientName: "LinkedIn"},
-	{find: "Pinterest", clientType: "App", clientName: "Pinterest"},
-	{find: "Snapchat", clientType: "App", clientName: "Snapchat"},
-	{find: "MicroMessenger", clientType: "App", clientName: "WeChat"},
-	{find: "ADG/", clientType: "Browser", clientName: "AOLDesktop"},
-	{find: "Silk", clientType: "Browser", clientName: "Silk"},
-	{find: "FxiOS", clientType: "Browser", clientName: "Firefox"},
-	{find: "Klarna", clientType: "Browser", clientName: "Firefox"},
-	{find: "Firefox", clientType: "Browser", clientName: "Firefox"},
-	{find: "EdgA/", clientType: "Browser", clientName: "Edge"},
-	{find: "EdgiOS/", clientType: "Browser", clientName: "Edge"},
-	{find: "EdgW/", clientType: "Browser", clientName: "Edge"},
-	{find: "Edg/", clientType: "Browser", clientName: "Edge"},
-	{find: "Edge/", clientType: "Browser", clientName: "Edge"},
-	{find: "MSIE", clientType: "Browser", clientName: "InternetExplorer"},
-	{find: "Trident", clientType: "Browser", clientName: "InternetExplorer"},
-	{find: "OPR/", clientType: "Browser", clientName: "Opera"},
-	{find: "OPT/", clientType: "Browser", clientName: "Opera"},
-	{find: "DuckDuckGo", clientType: "Browser", clientName: "DuckDuckGo"},
-	{find: "SamsungBrowser", clientType: "Browser", clientName: "SamsungBrowser"},
-	{find: "CriOS", clientType: "Browser", clientName: "Chrome"},
-	{find: "Chrome", clientType: "Browser", clientName: "Chrome"},
-	{find: "Safari", clientType: "Browser", clientName: "Safari"},
-}
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match indicates the appropriate field(s) for the supplied Find text. Matches are processed in order, and the
+// first Match containing a given field wins. Empty fields are ignored. A URL in the User-Agent string indicates a Bot,
+// overriding the matched ClientType, unless it's the project URL of a Library (e.g. node-fetch's GitHub repository).
+type Match struct {
+	// Find is the text to find in the cleaned User-Agent string (case-sensitive)
+	Find string `json:"find"`
+
+	// DeviceType indicates the device category (Desktop, Mobile, Tablet)
+	DeviceType DeviceType `json:"deviceType,omitempty"`
 
-// match indicates the appropriate field(s) for the supplied find text.
-type match struct {
-	find            string
-	deviceType      string
-	operatingSystem string
-	clientType      string
-	clientName      string
+	// OSName indicates the operating system name (Android, iOS, macOS, Windows, etc.)
+	OSName OSName `json:"osName,omitempty"`
+
+	// ClientType indicates the application category (App, Bot, Browser, Library)
+	ClientType ClientType `json:"clientType,omitempty"`
+
+	// ClientName indicates the application name (Chrome, Googlebot, etc.)
+	ClientName string `json:"clientName,omitempty"`
+
+	// Note is an optional comment explaining the Match (e.g. why it must precede another Match); it's not used
+	Note string `json:"note,omitempty"`
 }
 
 // UserAgent provides basic information about the user, extracted from an HTTP User-Agent request header.
@@ -86,31 +39,99 @@ type UserAgent struct {
 	// Fields contains parsed/cleaned segments of the User-Agent request header, used for analysis
 	Fields []string `json:"fields,omitempty"`
 
-	// ClientType indicates the application category (App, Bot, Browser, or Other)
-	ClientType string `json:"clientType,omitempty"`
+	// Products contains the products and comments in the User-Agent request header, in order
+	Products []Product `json:"products,omitempty"`
+
+	// ClientType indicates the application category (App, Bot, Browser, Library, or Other). Libraries are HTTP
+	// client libraries and command-line tools (curl, python-requests, okhttp, etc.), used for scripted traffic.
+	ClientType ClientType `json:"clientType,omitempty"`
 
 	// ClientName indicates the application name (Chrome, Googlebot, Edge, etc.)
 	ClientName string `json:"clientName,omitempty"`
 
-	// ClientVersion indicates the version of the application, if provided
+	// ClientFamily indicates the upstream browser of a browser client, based on its rendering engine (Chromium,
+	// Safari, Firefox, etc.). For example, Brave and Vivaldi are in the Chromium family, and all iOS browsers are in
+	// the Safari family.
+	ClientFamily string `json:"clientFamily,omitempty"`
+
+	// BotCategory indicates the purpose of a bot client (Search, SEO, Preview, AI, etc.). See LookupBot.
+	BotCategory BotCategory `json:"botCategory,omitempty"`
+
+	// Heuristic indicates that the client was identified as a bot by heuristics, rather than a pattern matcher
+	Heuristic bool `json:"heuristic,omitempty"`
+
+	// Suspicious indicates that the client is a security scanner or vulnerability probe (e.g. Nikto or sqlmap)
+	Suspicious bool `json:"suspicious,omitempty"`
+
+	// Automation indicates the browser automation framework (HeadlessChrome, PhantomJS, Cypress, Electron, etc.)
+	Automation string `json:"automation,omitempty"`
+
+	// ClientVersion indicates the major.minor version of the application, if provided
 	ClientVersion string `json:"clientVersion,omitempty"`
 
+	// ClientVersionFull indicates the complete version of the application, including patch and build numbers
+	ClientVersionFull string `json:"clientVersionFull,omitempty"`
+
+	// ClientVersionNumber indicates the structured version of the application, for comparisons
+	ClientVersionNumber Version `json:"-"`
+
 	// DeviceType indicates the general device category (Desktop, Mobile, Tablet, Other)
-	DeviceType string `json:"deviceType,omitempty"`
+	DeviceType DeviceType `json:"deviceType,omitempty"`
+
+	// DeviceVendor indicates the device manufacturer (Samsung, Google, Apple, etc.), if known
+	DeviceVendor string `json:"deviceVendor,omitempty"`
+
+	// DeviceModel indicates the device model identifier (e.g. SM-G991B or iPhone14,2), if provided
+	DeviceModel string `json:"deviceModel,omitempty"`
+
+	// DeviceName indicates the marketing name of the device model (e.g. Galaxy S21 or iPhone 13 Pro), if known
+	DeviceName string `json:"deviceName,omitempty"`
 
 	// OSName indicates the operating system running on the device (Android, Linux, iOS, macOS, Windows, etc.)
-	OSName string `json:"osName,omitempty"`
+	OSName OSName `json:"osName,omitempty"`
+
+	// EngineName indicates the browser rendering engine (Blink, WebKit, Gecko, Trident, EdgeHTML, or Presto)
+	EngineName EngineName `json:"engineName,omitempty"`
+
+	// EngineVersion indicates the major.minor version of the browser rendering engine, if provided
+	EngineVersion string `json:"engineVersion,omitempty"`
+
+	// Architecture indicates the CPU architecture of the device (x86, x86_64, arm, or arm64), if known
+	Architecture Architecture `json:"architecture,omitempty"`
 
-	// OSVersion indicates the operating system version, if available
+	// Bitness indicates the number of bits in the CPU architecture (32 or 64), or 0 if it's unknown
+	Bitness int `json:"bitness,omitempty"`
+
+	// OSVersion indicates the major.minor operating system version, if available
 	OSVersion string `json:"osVersion,omitempty"`
 
+	// OSVersionFull indicates the complete operating system version, including patch and build numbers
+	OSVersionFull string `json:"osVersionFull,omitempty"`
+
+	// OSVersionName indicates the operating system release name (e.g. XP, 7, Ventura, or Tiramisu), if known
+	OSVersionName string `json:"osVersionName,omitempty"`
+
+	// OSAPILevel indicates the Android API level of the operating system version (e.g. 33 for Android 13), if known
+	OSAPILevel int `json:"osApiLevel,omitempty"`
+
+	// OSVersionFrozen indicates that the operating system version is frozen by the browser (e.g. macOS 10.15.7 in
+	// Safari), so the actual version may be later
+	OSVersionFrozen bool `json:"osVersionFrozen,omitempty"`
+
+	// Reduced indicates that the User-Agent string was reduced by the browser, replacing the client version details
+	// (e.g. Chrome/110.0.0.0) and the Android device model (K) with fixed values
+	Reduced bool `json:"reduced,omitempty"`
+
+	// OSVersionNumber indicates the structured operating system version, for comparisons
+	OSVersionNumber Version `json:"-"`
+
 	// URL indicates the URL provided, typically for information about a bot/crawler.
 	URL string `json:"url,omitempty"`
 }
 
 // String supports the Stringer interface, providing an abbreviated user agent string.
 func (ua UserAgent) String() string {
-	s := ua.ClientType
+	s := string(ua.ClientType)
 	if ua.ClientName != "" {
 		s = s + " " + ua.ClientName
 	}
@@ -118,10 +139,10 @@ func (ua UserAgent) String() string {
 		s = s + " " + ua.ClientVersion
 	}
 	if ua.DeviceType != "" {
-		s = s + " " + ua.DeviceType
+		s = s + " " + string(ua.DeviceType)
 	}
 	if ua.OSName != "" {
-		s = s + " " + ua.OSName
+		s = s + " " + string(ua.OSName)
 	}
 	if ua.OSVersion != "" {
 		s = s + " " + ua.OSVersion
@@ -135,55 +156,55 @@ func (ua UserAgent) String() string {
 // Parse extracts client, device, and operating system information from the User-Agent request header provided,
 // returning a UserAgent. Note that the URL and versions will be empty if not provided. Other fields, however,
 // will be set to "Other" if the relevant information is not provided, or if the determination is inconclusive.
+// Parse uses the default Parser, with the built-in pattern matchers.
 func Parse(userAgent string) UserAgent {
+	return defaultParser.Parse(userAgent)
+}
+
+// parse extracts client, device, and operating system information from the User-Agent request header provided,
+// using the supplied compiled pattern matchers.
+func parse(userAgent string, rules *ruleSet) UserAgent {
 	ua := UserAgent{Header: unquote(userAgent)}
-	ua.Fields = parseFields(ua.Header)
-	cleaned := strings.Join(ua.Fields, " ")
-	// A URL always indicates a Bot
+	cleaned, fields := tokenize(ua.Header)
+	ua.Fields = fields
+	ua.Products = ParseProducts(ua.Header)
+	applyMatches(&ua, cleaned, rules)
+	// A URL indicates a Bot, unless it's the project URL of the matched Library. A crawler built on a Library is
+	// named by its own product token instead (see applyHeuristics).
 	if strings.Contains(ua.Header, "://") {
-		ua.ClientType = "Bot"
 		ua.URL = botURL(ua.Fields)
-	}
-	// Pattern matchers must be processed in order, and first match wins for the provided field(s)
-	for _, p := range patterns {
-		if strings.Contains(cleaned, p.find) {
-			if p.deviceType != "" && ua.DeviceType == "" {
-				ua.DeviceType = p.deviceType
-			}
-			if p.operatingSystem != "" && ua.OSName == "" {
-				ua.OSName = p.operatingSystem
-			}
-			if p.clientType != "" && ua.ClientType == "" {
-				ua.ClientType = p.clientType
-			}
-			if p.clientName != "" && ua.ClientName == "" {
-				ua.ClientName = p.clientName
-				ua.ClientVersion = clientVersion(ua.Fields, p.find)
-			}
+		if ua.ClientType == ClientTypeLibrary && !strings.Contains(ua.URL, ua.ClientName) {
+			ua.ClientName = ""
+			ua.setClientVersion("")
 		}
-		// Skip any remaining patterns if the UserAgent is complete
-		if ua.DeviceType != "" && ua.OSName != "" && ua.ClientType != "" && ua.ClientName != "" {
-			break
+		if ua.ClientType != ClientTypeLibrary || ua.ClientName == "" {
+			ua.ClientType = ClientTypeBot
 		}
 	}
+	ua.applyHeuristics()
 	// Post-processing: supply default values and update version numbers as appropriate
 	if ua.OSName == "" {
-		ua.OSName = "Other"
+		ua.OSName = OSNameOther
 	} else {
-		ua.OSVersion = osVersion(ua.Fields, ua.OSName)
+		ua.setOSVersion(osVersion(ua.Fields, ua.OSName))
 	}
 	if ua.DeviceType == "" {
-		ua.DeviceType = "Desktop"
+		ua.DeviceType = DeviceTypeDesktop
 	}
+	ua.setDevice()
+	ua.setArchitecture()
+	ua.setEngine()
+	ua.setFrozen()
+	ua.setAutomation()
 	if ua.ClientName == "" {
-		if ua.OSName == "iOS" || ua.OSName == "iPadOS" || ua.OSName == "macOS" {
+		if ua.OSName.IsApple() {
 			if ua.ClientType == "" {
-				ua.ClientType = "Browser"
+				ua.ClientType = ClientTypeBrowser
 			}
 			ua.ClientName = "Safari"
-		} else if ua.OSName == "Android" {
+		} else if ua.OSName == OSNameAndroid {
 			if ua.ClientType == "" {
-				ua.ClientType = "Browser"
+				ua.ClientType = ClientTypeBrowser
 			}
 			ua.ClientName = "Chrome"
 		} else {
@@ -193,23 +214,56 @@ func Parse(userAgent string) UserAgent {
 	if ua.ClientName == "Safari" {
 		ver := version(ua.Fields) // uses Version/99.9.9 for clientVersion
 		if ver != "" {
-			ua.ClientVersion = ver
+			ua.setClientVersion(ver)
+		} else if ua.ClientVersionNumber.Major >= 100 {
+			ua.setClientVersion("") // Safari/538.1 is a WebKit build number, not a Safari release
 		}
 	} else if ua.ClientName == "InternetExplorer" {
 		ver := releaseVersion(ua.Fields)
 		if ver != "" {
-			ua.ClientVersion = ver
+			ua.setClientVersion(ver)
 		}
+	} else if ua.ClientVersion == "" && ua.ClientType == ClientTypeBrowser && ua.EngineName == EngineNameGecko {
+		ua.setClientVersion(clientVersion(ua.Fields, "Firefox/")) // e.g. Waterfox Classic shares the Firefox version
 	}
 	if ua.ClientType == "" {
-		ua.ClientType = "Other"
+		ua.ClientType = ClientTypeOther
 	}
+	ua.setFamily()
+	ua.setBotCategory()
 	return ua
 }
 
+// applyMatches sets the UserAgent fields indicated by the pattern matchers whose Find text appears in the cleaned
+// User-Agent string. All the matching Find text is located in a single pass, and then the matchers are processed in
+// order, with the first match winning for the provided field(s).
+func applyMatches(ua *UserAgent, cleaned string, rules *ruleSet) {
+	rules.matcher.matchSet(cleaned).each(func(i int) bool {
+		p := rules.matches[i]
+		if p.DeviceType != "" && ua.DeviceType == "" {
+			ua.DeviceType = p.DeviceType
+		}
+		if p.OSName != "" && ua.OSName == "" {
+			ua.OSName = p.OSName
+		}
+		if p.ClientType != "" && ua.ClientType == "" {
+			ua.ClientType = p.ClientType
+		}
+		if p.ClientName != "" && ua.ClientName == "" {
+			ua.ClientName = p.ClientName
+			ua.setClientVersion(clientVersion(ua.Fields, p.Find))
+		}
+		// Skip any remaining matches if the UserAgent is complete
+		return ua.DeviceType == "" || ua.OSName == "" || ua.ClientType == "" || ua.ClientName == ""
+	})
+}
+
 // unquote strips single and double quotes from the provided User-Agent string.
 // Sometimes, the User-Agent string arrives unnecessarily quoted, as one would indicate a literal string in code.
 func unquote(ua string) string {
+	if !strings.ContainsAny(ua, "'\"") {
+		return ua
+	}
 	quotes := []rune("'\"")
 	rs := make([]rune, 0, len(ua))
 	for _, r := range ua {
@@ -220,37 +274,140 @@ func unquote(ua string) string {
 	return string(rs)
 }
 
+// noise lists the common, meaningless text removed (or abbreviated) by parseFields. Earlier entries take precedence
+// over later entries where they overlap (e.g. " like Mac OS X" is removed before "CPU " and "Mac OS X").
+var noise = []struct {
+	find    string
+	replace string
+}{
+	{"Mozilla/5.0", ""},
+	{"Safari/537.36", ""},
+	{"KHTML", ""},
+	{"like Gecko", ""},
+	{"compatible", ""},
+	{" like Mac OS X", ""},
+	{"CPU ", ""},
+	{"Intel ", ""},
+	{"Mac OS X", "OS"},
+	{"Windows NT", "Windows"},
+	{"WOW64", ""},
+	{"Win64", ""},
+	{"x86_64", ""},
+	{"x64", ""},
+	{"aarch64", ""},
+}
+
+// noiseIndex lists the noise entries starting with each byte, in order of precedence.
+var noiseIndex = func() (index [256][]int) {
+	for i, n := range noise {
+		index[n.find[0]] = append(index[n.find[0]], i)
+	}
+	return index
+}()
+
 // parseFields removes common, meaningless text and parses the User-Agent string into individual segments for analysis.
 func parseFields(ua string) []string {
-	// Remove meaningless text
-	s := strings.ReplaceAll(ua, "Mozilla/5.0", "")
-	s = strings.ReplaceAll(s, "Safari/537.36", "")
-	s = strings.ReplaceAll(s, "KHTML", "")
-	s = strings.ReplaceAll(s, "like Gecko", "")
-	s = strings.ReplaceAll(s, "compatible", "")
-	s = strings.ReplaceAll(s, " like Mac OS X", "")
-	s = strings.ReplaceAll(s, "CPU ", "")
-	s = strings.ReplaceAll(s, "Intel ", "")
-	s = strings.ReplaceAll(s, "Mac OS X", "OS")
-	s = strings.ReplaceAll(s, "Windows NT", "Windows")
-	s = strings.ReplaceAll(s, "WOW64", "")
-	s = strings.ReplaceAll(s, "Win64", "")
-	s = strings.ReplaceAll(s, "x86_64", "")
-	s = strings.ReplaceAll(s, "x64", "")
-	s = strings.ReplaceAll(s, "aarch64", "")
-	s = strings.ReplaceAll(s, "(", " ")
-	s = strings.ReplaceAll(s, ")", " ")
-	s = strings.ReplaceAll(s, "[", "")
-	s = strings.ReplaceAll(s, "]", "")
-	s = strings.ReplaceAll(s, ";", " ")
-	fields := strings.Fields(s)
-	ss := make([]string, 0, len(fields))
-	for _, f := range fields {
-		if f != "," && !strings.HasPrefix(f, "AppleWebKit") {
-			ss = append(ss, f)
+	_, fields := tokenize(ua)
+	return fields
+}
+
+// tokenize removes common, meaningless text and parses the User-Agent string into individual segments for analysis,
+// returning the cleaned User-Agent string (the segments separated by single spaces) and the segments themselves.
+// It makes a single pass over the User-Agent string, removing noise, brackets, and separators (parentheses,
+// semicolons, and whitespace), and dropping lone commas and AppleWebKit segments. The segments share the memory
+// of the cleaned string.
+func tokenize(ua string) (string, []string) {
+	var stack [256]byte // avoids a heap allocation for typical User-Agent strings
+	buf := stack[:0]
+	if len(ua) > len(stack) {
+		buf = make([]byte, 0, len(ua)) // noise replacements never lengthen the text
+	}
+	start := 0 // start of the current segment in buf
+	count := 0 // number of segments in buf
+	endField := func() {
+		if start == len(buf) {
+			return
 		}
+		f := buf[start:]
+		if (len(f) == 1 && f[0] == ',') || bytes.HasPrefix(f, appleWebKit) {
+			buf = buf[:start]
+			return
+		}
+		buf = append(buf, ' ')
+		start = len(buf)
+		count++
 	}
-	return ss
+	for i := 0; i < len(ua); {
+		c := ua[i]
+		if n, ok := noiseAt(ua, i); ok {
+			buf = append(buf, noise[n].replace...)
+			i += len(noise[n].find)
+			continue
+		}
+		switch c {
+		case '[', ']':
+			i++
+		case '(', ')', ';', ' ', '\t', '\n', '\v', '\f', '\r':
+			endField()
+			i++
+		default:
+			if c < utf8.RuneSelf {
+				buf = append(buf, c)
+				i++
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(ua[i:])
+			if unicode.IsSpace(r) {
+				endField()
+			} else {
+				buf = append(buf, ua[i:i+size]...)
+			}
+			i += size
+		}
+	}
+	endField()
+	if count == 0 {
+		return "", []string{}
+	}
+	cleaned := string(buf[:len(buf)-1]) // trim the trailing space
+	fields := make([]string, 0, count)
+	for start = 0; start < len(cleaned); {
+		end := strings.IndexByte(cleaned[start:], ' ')
+		if end == -1 {
+			end = len(cleaned) - start
+		}
+		fields = append(fields, cleaned[start:start+end])
+		start += end + 1
+	}
+	return cleaned, fields
+}
+
+// appleWebKit is the prefix of the AppleWebKit segments dropped by tokenize.
+var appleWebKit = []byte("AppleWebKit")
+
+// noiseAt returns the index of the noise entry found at the supplied position in the User-Agent string, if any.
+// An entry is skipped if an entry with higher precedence starts within it, so that overlapping entries are resolved
+// in order of precedence.
+func noiseAt(ua string, i int) (int, bool) {
+	for _, n := range noiseIndex[ua[i]] {
+		find := noise[n].find
+		if !strings.HasPrefix(ua[i:], find) {
+			continue
+		}
+		overlapped := false
+		for j := i + 1; j < i+len(find) && !overlapped; j++ {
+			for _, m := range noiseIndex[ua[j]] {
+				if m < n && strings.HasPrefix(ua[j:], noise[m].find) {
+					overlapped = true
+					break
+				}
+			}
+		}
+		if !overlapped {
+			return n, true
+		}
+	}
+	return 0, false
 }
 
 // botURL returns a URL, if present in the User-Agent string
@@ -267,19 +424,37 @@ func botURL(fields []string) string {
 	return ""
 }
 
-// version returns the major.minor version number, indicated by "Version" in the User-Agent string
+// setClientVersion sets the client version from the full version text provided, capturing the major.minor
+// version in ClientVersion, ignoring patch details.
+func (ua *UserAgent) setClientVersion(ver string) {
+	ua.ClientVersion = shortVersion(ver)
+	ua.ClientVersionFull = ver
+	ua.ClientVersionNumber = ParseVersion(ver)
+}
+
+// setOSVersion sets the operating system version from the full version text provided, capturing the major.minor
+// version in OSVersion, if the text is numeric, and the name of the release (e.g. Windows 6.1 is Windows 7).
+func (ua *UserAgent) setOSVersion(ver string) {
+	ua.OSVersion = majorMinorVersion(ver)
+	ua.OSVersionName, ua.OSAPILevel = "", 0
+	if ua.OSVersion == "" {
+		ua.OSVersionFull = ""
+		ua.OSVersionNumber = Version{}
+		return
+	}
+	ua.OSVersionFull = fullVersion(ver)
+	ua.OSVersionNumber = ParseVersion(ver)
+	if r, ok := lookupRelease(ua.OSName, ua.OSVersionNumber); ok {
+		ua.OSVersionName, ua.OSAPILevel = r.Name, r.APILevel
+	}
+}
+
+// version returns the full version number, indicated by "Version" in the User-Agent string
 // The Safari browser uses Version to indicate its version number.
 func version(fields []string) string {
 	for _, f := range fields {
 		if strings.HasPrefix(f, "Version/") && len(f) > 8 {
-			i := strings.Index(f, "/") + 1
-			// capture major.minor version, ignoring patch details
-			segments := strings.Split(f[i:], ".")
-			if len(segments) == 1 {
-				return segments[0]
-			} else if len(segments) >= 2 {
-				return strings.Join(segments[:2], ".")
-			}
+			return f[8:]
 		}
 	}
 	return ""
@@ -297,38 +472,51 @@ func releaseVersion(fields []string) string {
 	return ""
 }
 
-// clientVersion splits a clientName/clientVersion field on the slash, returning a major.minor version,
-// ignoring patch details. Most clients use this syntax (e.g. Chrome/100.0.4896.75).
+// clientVersion splits a clientName/clientVersion field on the slash, returning the full version, ignoring any
+// subsequent slash-separated text. Most clients use this syntax (e.g. Chrome/100.0.4896.75).
 func clientVersion(fields []string, clientName string) string {
 	for _, f := range fields {
 		if strings.Contains(f, clientName) {
 			_, ver, found := strings.Cut(f, "/")
 			if found {
-				// capture major.minor version, ignoring patch details
-				segments := strings.Split(ver, ".")
-				if len(segments) == 1 {
-					return segments[0]
-				} else if len(segments) >= 2 {
-					return strings.Join(segments[:2], ".")
-				}
+				ver, _, _ = strings.Cut(ver, "/")
+				return ver
 			}
 		}
 	}
 	return ""
 }
 
-// osVersion returns an operating system version, if available. It's usually space-separated after the operating
-// system name in the User-Agent string. Therefore, it's often the next field after the operating system name.
-func osVersion(fields []string, osName string) string {
+// shortVersion returns the major.minor version from the provided version text, ignoring patch details.
+func shortVersion(ver string) string {
+	major, rest, found := strings.Cut(ver, ".")
+	if !found {
+		return ver
+	}
+	minor, _, _ := strings.Cut(rest, ".")
+	return ver[:len(major)+1+len(minor)]
+}
+
+// fullVersion returns a cleaned version number from the provided numeric text, with all its segments separated by
+// periods (e.g. Apple's 15_4_1 becomes 15.4.1), and any trailing separator trimmed.
+func fullVersion(ver string) string {
+	return strings.TrimRight(strings.ReplaceAll(ver, "_", "."), ".")
+}
+
+// osVersion returns the full operating system version text, if available. It's usually space-separated after the
+// operating system name in the User-Agent string. Therefore, it's often the next field after the operating system
+// name. The text is only a version if it's numeric (see majorMinorVersion).
+func osVersion(fields []string, osName OSName) string {
 	if osName == "" {
 		return ""
 	}
-	if osName == "iOS" || osName == "iPadOS" || osName == "macOS" {
-		osName = "OS"
+	find := string(osName)
+	if osName.IsApple() {
+		find = "OS"
 	}
 	for i, f := range fields {
-		if f == osName && i+1 < len(fields) {
-			return majorMinorVersion(fields[i+1])
+		if f == find && i+1 < len(fields) {
+			return fields[i+1]
 		}
 	}
 	return ""
@@ -341,19 +529,19 @@ func majorMinorVersion(ver string) string {
 	if !isDigits(ver) {
 		return ""
 	}
-	var segments []string
+	sep := "."
 	if strings.Contains(ver, "_") {
-		segments = strings.Split(ver, "_")
-	} else {
-		segments = strings.Split(ver, ".")
+		sep = "_"
 	}
-	if len(segments) >= 2 {
-		return strings.Join(segments[:2], ".")
+	major, rest, found := strings.Cut(ver, sep)
+	if !found {
+		return ver
 	}
-	if len(segments) == 1 {
-		return segments[0] // trimmed trailing separator
+	minor, _, _ := strings.Cut(rest, sep)
+	if sep == "." {
+		return ver[:len(major)+1+len(minor)] // avoids an allocation
 	}
-	return ver // original string
+	return major + "." + minor
 }
 
 // isDigits returns true if the supplied text contains only valid numeric digits in a major.minor.patch version
@@ -1,6 +1,11 @@
 package user_agent
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -121,6 +126,77 @@ func Test_parseFields(t *testing.T) {
 	}
 }
 
+// parseFieldsReference is the original chain of strings.ReplaceAll calls, used as a reference for parseFields.
+func parseFieldsReference(ua string) []string {
+	s := strings.ReplaceAll(ua, "Mozilla/5.0", "")
+	s = strings.ReplaceAll(s, "Safari/537.36", "")
+	s = strings.ReplaceAll(s, "KHTML", "")
+	s = strings.ReplaceAll(s, "like Gecko", "")
+	s = strings.ReplaceAll(s, "compatible", "")
+	s = strings.ReplaceAll(s, " like Mac OS X", "")
+	s = strings.ReplaceAll(s, "CPU ", "")
+	s = strings.ReplaceAll(s, "Intel ", "")
+	s = strings.ReplaceAll(s, "Mac OS X", "OS")
+	s = strings.ReplaceAll(s, "Windows NT", "Windows")
+	s = strings.ReplaceAll(s, "WOW64", "")
+	s = strings.ReplaceAll(s, "Win64", "")
+	s = strings.ReplaceAll(s, "x86_64", "")
+	s = strings.ReplaceAll(s, "x64", "")
+	s = strings.ReplaceAll(s, "aarch64", "")
+	s = strings.ReplaceAll(s, "(", " ")
+	s = strings.ReplaceAll(s, ")", " ")
+	s = strings.ReplaceAll(s, "[", "")
+	s = strings.ReplaceAll(s, "]", "")
+	s = strings.ReplaceAll(s, ";", " ")
+	fields := strings.Fields(s)
+	ss := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != "," && !strings.HasPrefix(f, "AppleWebKit") {
+			ss = append(ss, f)
+		}
+	}
+	return ss
+}
+
+// Test_tokenize checks that the single-pass tokenizer produces the same fields as the original chain of
+// strings.ReplaceAll calls, for some edge cases and all the sample User-Agent strings.
+func Test_tokenize(t *testing.T) {
+	uas := []string{
+		"CPU like Mac OS X",
+		"Mozilla/5.0Mozilla/5.0",
+		"Ma[c] OS X",
+		"tab\tand\u00a0no-break\u0085spaces",
+		"(,) , ,",
+		"AppleWebKit/537.36 AppleWebKitX KHTML",
+		strings.Repeat("Mozilla/5.0 (Windows NT 10.0; Win64; x64) ", 10),
+	}
+	data, err := os.ReadFile("cmd/sample_data/user_agents.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samples []struct {
+		StringCounts map[string]int `json:"stringCounts"`
+	}
+	if err = json.Unmarshal(data, &samples); err != nil {
+		t.Fatal(err)
+	}
+	for _, sample := range samples {
+		for ua := range sample.StringCounts {
+			uas = append(uas, ua)
+		}
+	}
+	for _, ua := range uas {
+		cleaned, fields := tokenize(ua)
+		expected := parseFieldsReference(ua)
+		if !reflect.DeepEqual(fields, expected) {
+			t.Errorf("fields for %q\nexpected: %q\nreceived: %q", ua, expected, fields)
+		}
+		if cleaned != strings.Join(expected, " ") {
+			t.Errorf("cleaned %q\nexpected: %q\nreceived: %q", ua, strings.Join(expected, " "), cleaned)
+		}
+	}
+}
+
 func Test_majorMinorVersion(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -257,13 +333,23 @@ func TestBots(t *testing.T) {
 		"Mozilla/5.0 (Windows NT 6.1; Win64; x64) AppleWebit/537.36 (KHTML, like Gecko) Chrome/63.0.3239.0 Safari/537.36 (compatible; Yeti/1.1; +http://naver.me/spd)",
 		"Mozilla/5.0 (Windows NT 6.1; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/69.0.3497.81 YisouSpider/5.0 Safari/537.36",
 		"Mozilla/5.0 (iPhone; CPU iPhone OS 10_3 like Mac OS X) AppleWebKit/602.1.50 (KHTML, like Gecko) CriOS/56.0.2924.75 Mobile/14E5239e YisouSpider/5.0 Safari/602.1",
+		"Mozilla/5.00 (Nikto/2.1.6) (Evasions:None) (Test:Port Check)",
+		"sqlmap/1.7.2#stable (https://sqlmap.org)",
+		"Mozilla/5.0 (compatible; Nmap Scripting Engine; https://nmap.org/book/nse.html)",
+		"masscan/1.3 (https://github.com/robertdavidgraham/masscan)",
+		"Mozilla/5.0 zgrab/0.x",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/109.0.0.0 Safari/537.36 Nuclei - Open-source project (github.com/projectdiscovery/nuclei)",
+		"WPScan v3.8.22 (https://wpscan.com/wordpress-security-scanner)",
+		"Mozilla/5.0 (compatible; MSIE 9.0; Windows NT 6.1; Trident/5.0; Acunetix Web Vulnerability Scanner)",
+		"Mozilla/5.0 (compatible; http://abc123.burpcollaborator.net)",
+		"Mozilla/5.0 [en] (X11, U; OpenVAS-VT 21.4.4)",
 	}
 	expected := []string{
 		"Bot Baiduspider 2.0 Desktop Other http://www.baidu.com/search/spider.html",
 		"Bot Baiduspider 2.0 Mobile iOS 9.1 http://www.baidu.com/search/spider.html",
 		"Bot Cincraw 1.0 Desktop Other http://cincrawdata.net/bot/",
-		"Bot HeadlessChrome 101.0 Desktop Linux",
-		"Bot HeadlessChrome 78.0 Desktop Windows 10.0",
+		"Bot Chrome 101.0 Desktop Linux",
+		"Bot Chrome 78.0 Desktop Windows 10.0",
 		"Bot HubSpot Desktop Other https://www.hubspot.com",
 		"Bot Linespider 1.1 Desktop Windows 6.1 https://lin.ee/4dwXkTH",
 		"Bot PagePeeker 3.0 Desktop Windows 6.3 https://pagepeeker.com/robots/",
@@ -277,10 +363,128 @@ func TestBots(t *testing.T) {
 		"Bot Yeti 1.1 Desktop Windows 6.1 http://naver.me/spd",
 		"Bot YisouSpider 5.0 Desktop Windows 6.1",
 		"Bot YisouSpider 5.0 Mobile iOS 10.3",
+		"Bot Nikto 2.1 Desktop Other",
+		"Bot sqlmap 1.7 Desktop Other https://sqlmap.org",
+		"Bot Nmap Desktop Other https://nmap.org/book/nse.html",
+		"Bot masscan 1.3 Desktop Other https://github.com/robertdavidgraham/masscan",
+		"Bot zgrab 0.x Desktop Other",
+		"Bot Nuclei Desktop Windows 10.0",
+		"Bot WPScan Desktop Other https://wpscan.com/wordpress-security-scanner",
+		"Bot Acunetix Desktop Windows 6.1",
+		"Bot BurpCollaborator Desktop Other http://abc123.burpcollaborator.net",
+		"Bot OpenVAS Desktop Other",
 	}
 	parseCompare(uas, expected, t)
 }
 
+// TestAIBots tests the User-Agent strings of AI/LLM crawlers and fetchers.
+func TestAIBots(t *testing.T) {
+	uas := []string{
+		"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; GPTBot/1.2; +https://openai.com/gptbot)",
+		"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko); compatible; ChatGPT-User/1.0; +https://openai.com/bot",
+		"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko); compatible; OAI-SearchBot/1.0; +https://openai.com/searchbot",
+		"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; ClaudeBot/1.0; +claudebot@anthropic.com)",
+		"Mozilla/5.0 (compatible; anthropic-ai/1.0; +http://www.anthropic.com/bot.html)",
+		"CCBot/2.0 (https://commoncrawl.org/faq/)",
+		"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; PerplexityBot/1.0; +https://perplexity.ai/perplexitybot)",
+		"Mozilla/5.0 (Linux; Android 5.0) AppleWebKit/537.36 (KHTML, like Gecko) Mobile Safari/537.36 (compatible; Bytespider; spider-feedback@bytedance.com)",
+		"Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; Amazonbot/0.1; +https://developer.amazon.com/support/amazonbot) Chrome/119.0.6045.214 Safari/537.36",
+		"meta-externalagent/1.1 (+https://developers.facebook.com/docs/sharing/webmasters/crawler)",
+	}
+	expected := []string{
+		"Bot GPTBot 1.2 Desktop Other https://openai.com/gptbot",
+		"Bot ChatGPT-User 1.0 Desktop Other https://openai.com/bot",
+		"Bot OAI-SearchBot 1.0 Desktop Other https://openai.com/searchbot",
+		"Bot ClaudeBot 1.0 Desktop Other",
+		"Bot anthropic-ai 1.0 Desktop Other http://www.anthropic.com/bot.html",
+		"Bot CCBot 2.0 Desktop Other https://commoncrawl.org/faq/",
+		"Bot PerplexityBot 1.0 Desktop Other https://perplexity.ai/perplexitybot",
+		"Bot Bytespider Mobile Android 5.0",
+		"Bot Amazonbot 0.1 Desktop Other https://developer.amazon.com/support/amazonbot",
+		"Bot Meta-ExternalAgent 1.1 Desktop Other https://developers.facebook.com/docs/sharing/webmasters/crawler",
+	}
+	parseCompare(uas, expected, t)
+	for _, ua := range uas {
+		if c := Parse(ua).BotCategory; c != BotCategoryAI {
+			t.Errorf("expected AI bot category, received %q: %s", c, ua)
+		}
+	}
+}
+
+// TestPreviewBots tests the User-Agent strings of social and chat link-preview fetchers.
+func TestPreviewBots(t *testing.T) {
+	uas := []string{
+		"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+		"Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+		"Twitterbot/1.0",
+		"Mozilla/5.0 (compatible; Discordbot/2.0; +https://discordapp.com)",
+		"WhatsApp/2.23.20.0 A",
+		"TelegramBot (like TwitterBot)",
+		"LinkedInBot/1.0 (compatible; Mozilla/5.0; Apache-HttpClient +http://www.linkedin.com)",
+		"Mozilla/5.0 (Windows NT 6.1; WOW64) SkypeUriPreview Preview/0.5 skype-url-preview@microsoft.com",
+		"Iframely/1.3.1 (+https://iframely.com/docs/about)",
+		"Mozilla/5.0 (compatible; Embedly/0.2; +http://support.embed.ly/)",
+	}
+	expected := []string{
+		"Bot FacebookBot 1.1 Desktop Other http://www.facebook.com/externalhit_uatext.php",
+		"Bot Slackbot-LinkExpanding Desktop Other https://api.slack.com/robots",
+		"Bot Twitterbot 1.0 Desktop Other",
+		"Bot Discordbot 2.0 Desktop Other https://discordapp.com",
+		"Bot WhatsApp 2.23 Desktop Other",
+		"Bot TelegramBot Desktop Other",
+		"Bot LinkedInBot 1.0 Desktop Other http://www.linkedin.com",
+		"Bot SkypeUriPreview Desktop Windows 6.1",
+		"Bot Iframely 1.3 Desktop Other https://iframely.com/docs/about",
+		"Bot Embedly 0.2 Desktop Other http://support.embed.ly/",
+	}
+	parseCompare(uas, expected, t)
+	for _, ua := range uas {
+		if c := Parse(ua).BotCategory; c != BotCategoryPreview {
+			t.Errorf("expected Preview bot category, received %q: %s", c, ua)
+		}
+	}
+}
+
+// TestMonitoringBots tests the User-Agent strings of uptime monitors and synthetic-testing agents.
+func TestMonitoringBots(t *testing.T) {
+	uas := []string{
+		"Mozilla/5.0+(compatible; UptimeRobot/2.0; http://www.uptimerobot.com/)",
+		"Pingdom.com_bot_version_1.4_(http://www.pingdom.com/)",
+		"Mozilla/5.0 (Unknown; Linux x86_64) AppleWebKit/534.34 (KHTML, like Gecko) PingdomTMS/2020.2 Safari/534.34",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/71.0.3578.98 Safari/537.36 StatusCake",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/95.0.4638.69 Safari/537.36 DatadogSynthetics",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.64 Safari/537.36 (compatible; NewRelicSynthetics/1.0; +https://docs.newrelic.com/docs/synthetics/)",
+		"Mozilla/5.0 (Windows NT 6.1; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/73.0.3683.86 Safari/537.36 Site24x7",
+		"Checkly/1.0 (https://www.checklyhq.com)",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/110.0.5481.177 Safari/537.36 Checkly, https://www.checklyhq.com",
+		"Better Uptime Bot Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/103.0.0.0 Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 11; moto g power (2022)) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/109.0.0.0 Mobile Safari/537.36 Chrome-Lighthouse",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/109.0.5414.119 Safari/537.36 Chrome-Lighthouse",
+		"Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X Build/MMB29P) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/41.0.2272.96 Mobile Safari/537.36 (compatible; Google Page Speed Insights)",
+	}
+	expected := []string{
+		"Bot UptimeRobot 2.0 Desktop Other http://www.uptimerobot.com/",
+		"Bot Pingdom Desktop Other http://www.pingdom.com/",
+		"Bot Pingdom 2020.2 Desktop Linux",
+		"Bot StatusCake Desktop Windows 10.0",
+		"Bot DatadogSynthetics Desktop Linux",
+		"Bot NewRelicSynthetics 1.0 Desktop Linux https://docs.newrelic.com/docs/synthetics/",
+		"Bot Site24x7 Desktop Windows 6.1",
+		"Bot Checkly 1.0 Desktop Other https://www.checklyhq.com",
+		"Bot Checkly Desktop Linux https://www.checklyhq.com",
+		"Bot BetterUptime Desktop Windows 10.0",
+		"Bot Lighthouse Mobile Android 11",
+		"Bot Lighthouse Desktop Linux",
+		"Bot Lighthouse Mobile Android 6.0",
+	}
+	parseCompare(uas, expected, t)
+	for _, ua := range uas {
+		if c := Parse(ua).BotCategory; c != BotCategoryMonitoring {
+			t.Errorf("expected Monitoring bot category, received %q: %s", c, ua)
+		}
+	}
+}
+
 // TestApplications tests various application User-Agent strings.
 func TestApplications(t *testing.T) {
 	uas := []string{
@@ -330,6 +534,43 @@ func TestApplications(t *testing.T) {
 	parseCompare(uas, expected, t)
 }
 
+// TestLibraries tests HTTP client libraries and command-line tools
+func TestLibraries(t *testing.T) {
+	uas := []string{
+		"curl/7.88.1",
+		"Wget/1.21.2",
+		"Go-http-client/1.1",
+		"python-requests/2.28.1",
+		"Python/3.10 aiohttp/3.8.4",
+		"okhttp/4.9.3",
+		"Apache-HttpClient/4.5.13 (Java/17.0.2)",
+		"Java/1.8.0_292",
+		"axios/1.4.0",
+		"node-fetch/1.0 (+https://github.com/bitinn/node-fetch)",
+		"libwww-perl/6.67",
+		"Mozilla/5.0 (Windows NT 10.0; Microsoft Windows 10.0.19045; en-US) PowerShell/7.3.4",
+		"Dalvik/2.1.0 (Linux; U; Android 11; SM-G991B Build/RP1A.200720.012)",
+		"AcmeFeeds/1.0 (+https://acme.example/feeds) python-requests/2.28.2",
+	}
+	expected := []string{
+		"Library curl 7.88 Desktop Other",
+		"Library Wget 1.21 Desktop Other",
+		"Library Go-http-client 1.1 Desktop Other",
+		"Library python-requests 2.28 Desktop Other",
+		"Library aiohttp 3.8 Desktop Other",
+		"Library okhttp 4.9 Desktop Other",
+		"Library Apache-HttpClient 4.5 Desktop Other",
+		"Library Java 1.8 Desktop Other",
+		"Library axios 1.4 Desktop Other",
+		"Library node-fetch 1.0 Desktop Other https://github.com/bitinn/node-fetch",
+		"Library libwww-perl 6.67 Desktop Other",
+		"Library PowerShell 7.3 Desktop Windows 10.0",
+		"Library Dalvik 2.1 Tablet Android 11",
+		"Bot AcmeFeeds 1.0 Desktop Other https://acme.example/feeds", // a crawler's contact URL
+	}
+	parseCompare(uas, expected, t)
+}
+
 // TestBrowsers tests various less-common browser User-Agent strings
 func TestBrowsers(t *testing.T) {
 	uas := []string{
@@ -358,11 +599,18 @@ func TestBrowsers(t *testing.T) {
 		"Mozilla/5.0 (iPhone; CPU iPhone OS 15_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 DuckDuckGo/7 Safari/605.1.15",
 		"Mozilla/5.0 (Linux; Android 12) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/101.0.4951.61 Mobile DuckDuckGo/5 Safari/537.36",
 		"Mozilla/5.0 (Linux; Android 12) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/100.0.4896.127 DuckDuckGo/5 Safari/537.36",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/102.0.5005.63 Safari/537.36 Vivaldi/5.3.2679.50",
+		"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/102.0.5005.63 Safari/537.36 Vivaldi/5.3.2679.50",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/100.0.4896.143 YaBrowser/22.5.0.1792 Yowser/2.5 Safari/537.36",
+		"Mozilla/5.0 (Linux; U; Android 11; en; V2149 Build/RP1A.200720.012) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/57.0.2987.108 UCBrowser/12.10.0.1163 UCTurbo/1.10.6.900 Mobile Safari/537.36",
+		"Mozilla/5.0 (Windows NT 10.0; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.25 Safari/537.36 Core/1.70.3877.400 QQBrowser/10.8.4506.400",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/100.0.4896.57 Whale/3.14.133.23 Safari/537.36",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Brave Chrome/79.0.3945.88 Safari/537.36",
 	}
 	expected := []string{
 		"Browser AOLDesktop 11.0 Desktop Windows 6.2",
 		"Browser Silk 98.7 Tablet Android 9",
-		"Browser Silk 100.1 Tablet Android 7.1",
+		"Browser Silk 100.1 TV Android 7.1",
 		"Browser InternetExplorer Desktop Windows",
 		"Browser InternetExplorer Desktop Windows 10.0",
 		"Browser InternetExplorer 11.0 Desktop Windows 10.0",
@@ -385,6 +633,13 @@ func TestBrowsers(t *testing.T) {
 		"Browser DuckDuckGo 7 Mobile iOS 15.5",
 		"Browser DuckDuckGo 5 Mobile Android 12",
 		"Browser DuckDuckGo 5 Tablet Android 12",
+		"Browser Vivaldi 5.3 Desktop Windows 10.0",
+		"Browser Vivaldi 5.3 Desktop Linux",
+		"Browser Yandex 22.5 Desktop Windows 10.0",
+		"Browser UCBrowser 12.10 Mobile Android 11",
+		"Browser QQBrowser 10.8 Desktop Windows 10.0",
+		"Browser Whale 3.14 Desktop Windows 10.0",
+		"Browser Brave Desktop Windows 10.0",
 	}
 	parseCompare(uas, expected, t)
 }
@@ -434,13 +689,13 @@ func TestFirefox(t *testing.T) {
 		"Browser Firefox 100.0 Desktop Windows 6.1",
 		"Browser Firefox 52.0 Desktop Windows 5.1",
 		"Browser Firefox 81.0 Desktop Windows",
-		"Browser Firefox 65.0 Desktop Windows 10.0",
+		"Browser IceDragon 65.0 Desktop Windows 10.0",
 		"Browser Firefox 38.0 Desktop Linux",
-		"Browser Firefox 68.0 Desktop Windows 5.1",
-		"Browser Firefox 68.0 Desktop Windows 6.3",
-		"Browser Firefox 91.0 Desktop macOS 10.15",
-		"Browser Firefox 91.0 Desktop Windows 10.0",
-		"Browser Firefox 56.2 Desktop Windows 6.1",
+		"Browser Mypal 29.3 Desktop Windows 5.1",
+		"Browser PaleMoon 29.4 Desktop Windows 6.3",
+		"Browser Waterfox 91.10 Desktop macOS 10.15",
+		"Browser Waterfox 91.10 Desktop Windows 10.0",
+		"Browser Waterfox 56.2 Desktop Windows 6.1",
 		"Browser Firefox Desktop Linux",
 	}
 	parseCompare(uas, expected, t)
@@ -514,7 +769,7 @@ func TestChrome(t *testing.T) {
 		"Browser Chrome 102.0 Desktop ChromeOS",
 		"Browser Chrome 101.0 Desktop Linux",
 		"Browser Chrome 99.0 Desktop Linux",
-		"Browser Chrome 74.0 Desktop macOS 10.13",
+		"Bot Lighthouse Desktop macOS 10.13",
 		"Browser Chrome 89.0 Desktop macOS 12.3",
 		"Browser Chrome 67.0 Desktop Windows 10.0",
 		"Browser Chrome 101.0 Desktop Windows 10.0",
@@ -523,7 +778,7 @@ func TestChrome(t *testing.T) {
 		"Browser Chrome 101.0 Tablet Android 12",
 		"Browser Chrome 100.0 Tablet Android 10",
 		"Browser Chrome 11.0 Desktop Linux",
-		"Browser Chrome 79.0 Desktop Linux",
+		"Browser Chrome 79.0 TV Linux",
 	}
 	parseCompare(uas, expected, t)
 }
@@ -553,19 +808,83 @@ func TestSafari(t *testing.T) {
 	parseCompare(uas, expected, t)
 }
 
+// TestTVs tests smart TV, streaming stick, and set-top box User-Agent strings.
+func TestTVs(t *testing.T) {
+	uas := []string{
+		"Mozilla/5.0 (SMART-TV; LINUX; Tizen 6.0) AppleWebKit/537.36 (KHTML, like Gecko) 76.0.3809.146/6.0 TV Safari/537.36",
+		"Mozilla/5.0 (Web0S; Linux/SmartTV) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/79.0.3945.79 Safari/537.36 WebAppManager",
+		"Roku/DVP-9.10 (519.10E04111A)",
+		"AppleCoreMedia/1.0.0.20J373 (Apple TV; U; CPU OS 16_0 like Mac OS X; en_us)",
+		"Mozilla/5.0 (Linux; Android 9; SHIELD Android TV Build/PPR1.180610.011) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.5359.128 Mobile Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 10; BRAVIA 4K VH2 Build/QTG3.200305.006.S292; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/109.0.5414.117 Mobile Safari/537.36 GoogleTV",
+		"Mozilla/5.0 (Linux; Android 9; AFTKA Build/PS7633.3445N; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/108.0.5359.160 Mobile Safari/537.36",
+		"Mozilla/5.0 (X11; Linux armv7l) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/72.0.3626.105 Safari/537.36 CrKey/1.0.999999 VIZIO SmartCast(Conjure/MTKE-5.8.16.3-1 FW/1.0.1.9 Model/M55-F6)",
+		"Mozilla/5.0 (X11; Linux armv7l) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/31.0.1650.0 Safari/537.36 CrKey/1.56.500000 DeviceType/Chromecast",
+		"HbbTV/1.5.1 (+DRM; Samsung; SmartTV2021; T-KSU2EDEUC-1401.0; ; ) Tizen/6.0 (+TVPLUS+SmartHubLink) Chrome/76.0.3809.146 Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 9; KFTRWI) AppleWebKit/537.36 (KHTML, like Gecko) Silk/98.7.2 like Chrome/98.0.4758.136 Safari/537.36",
+		"Mozilla/5.0 (Linux; Android 12; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/110.0.5481.153 Mobile Safari/537.36 AFTVnews/2.1",
+		"Mozilla/5.0 (SMART-TV; X11; Linux armv7l) AppleWebKit/537.42 (KHTML, like Gecko) Safari/537.42",
+	}
+	expected := []string{
+		"Other Other TV Tizen 6.0",
+		"Browser Chrome 79.0 TV webOS",
+		"Other Other TV Roku",
+		"Browser Safari TV tvOS 16.0",
+		"Browser Chrome 108.0 TV Android 9",
+		"Browser Chrome 109.0 TV Android 10",
+		"Browser Chrome 108.0 TV Android 9",
+		"Browser Chrome 72.0 TV Linux",
+		"Browser Chrome 31.0 TV Linux",
+		"Browser Chrome 76.0 TV Tizen",
+		"Browser Silk 98.7 Tablet Android 9",     // Fire tablet, not Fire TV
+		"Browser Chrome 110.0 Mobile Android 12", // AFT outside the device model
+		"Browser Safari TV Linux",                // Samsung Orsay, prior to Tizen
+	}
+	parseCompare(uas, expected, t)
+}
+
+// TestFullVersions tests that the complete client and operating system versions are retained.
+func TestFullVersions(t *testing.T) {
+	cases := []struct {
+		name      string
+		ua        string
+		clientVer string
+		osVer     string
+	}{
+		{"Chrome", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/101.0.4951.67 Safari/537.36", "101.0.4951.67", "10.0"},
+		{"Safari", "Mozilla/5.0 (iPad; CPU OS 15_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.4.1 Mobile/15E148 Safari/605.1.15", "15.4.1", "15.4.1"},
+		{"Firefox", "Mozilla/5.0 (Android 12; Mobile; rv:101.0) Gecko/101.0 Firefox/101.0.1", "101.0.1", "12"},
+		{"InternetExplorer", "Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko", "11.0", "6.1"},
+		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", "2.1", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ua := Parse(c.ua)
+			if ua.ClientVersionFull != c.clientVer {
+				t.Errorf("client version expected/received:\n%s\n%s", c.clientVer, ua.ClientVersionFull)
+			}
+			if ua.OSVersionFull != c.osVer {
+				t.Errorf("OS version expected/received:\n%s\n%s", c.osVer, ua.OSVersionFull)
+			}
+		})
+	}
+}
+
+// benchmarkAgents are User-Agent strings used in benchmarks.
+var benchmarkAgents = []struct {
+	name string
+	ua   string
+}{
+	{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"},
+	{"Chrome", "Mozilla/5.0 (X11; CrOS x86_64 14695.25.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/102.0.0.0 Safari/537.36"},
+	{"Firefox", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0"},
+	{"Safari", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1"},
+}
+
 // BenchmarkParse checks performance on parsing different User-Agent strings.
 // Note that some are detected earlier in the cascade (e.g. bots and applications).
 func BenchmarkParse(b *testing.B) {
-	agents := []struct {
-		name string
-		ua   string
-	}{
-		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"},
-		{"Chrome", "Mozilla/5.0 (X11; CrOS x86_64 14695.25.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/102.0.0.0 Safari/537.36"},
-		{"Firefox", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:102.0) Gecko/20100101 Firefox/102.0"},
-		{"Safari", "Mozilla/5.0 (iPhone; CPU iPhone OS 15_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.5 Mobile/15E148 Safari/604.1"},
-	}
-	for _, a := range agents {
+	for _, a := range benchmarkAgents {
 		b.Run("Parse-"+a.name, func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				ua := Parse(a.ua)
@@ -575,3 +894,87 @@ func BenchmarkParse(b *testing.B) {
 		})
 	}
 }
+
+// applyMatchesLinear is the original strings.Contains cascade, used as a reference for applyMatches.
+func applyMatchesLinear(ua *UserAgent, cleaned string, matches []Match) {
+	for _, p := range matches {
+		if strings.Contains(cleaned, p.Find) {
+			if p.DeviceType != "" && ua.DeviceType == "" {
+				ua.DeviceType = p.DeviceType
+			}
+			if p.OSName != "" && ua.OSName == "" {
+				ua.OSName = p.OSName
+			}
+			if p.ClientType != "" && ua.ClientType == "" {
+				ua.ClientType = p.ClientType
+			}
+			if p.ClientName != "" && ua.ClientName == "" {
+				ua.ClientName = p.ClientName
+				ua.setClientVersion(clientVersion(ua.Fields, p.Find))
+			}
+		}
+		if ua.DeviceType != "" && ua.OSName != "" && ua.ClientType != "" && ua.ClientName != "" {
+			break
+		}
+	}
+}
+
+// benchmarkMatches returns a table of n pattern matchers, with synthetic bot matchers inserted after the built-in
+// bot matchers, so that browsers are detected after scanning past all the bots.
+func benchmarkMatches(n int) []Match {
+	last := 0
+	for i, m := range patterns {
+		if m.ClientType == "Bot" {
+			last = i
+		}
+	}
+	matches := append([]Match(nil), patterns[:last+1]...)
+	for i := 0; len(matches) < n-(len(patterns)-last-1); i++ {
+		name := fmt.Sprintf("Synthetic%03dBot", i)
+		matches = append(matches, Match{Find: name, ClientType: "Bot", ClientName: name})
+	}
+	return append(matches, patterns[last+1:]...)
+}
+
+// Test_applyMatches checks that the automaton produces the same results as the strings.Contains cascade.
+func Test_applyMatches(t *testing.T) {
+	matches := benchmarkMatches(500)
+	rules := compileRules(matches)
+	for _, agent := range benchmarkAgents {
+		t.Run(agent.name, func(t *testing.T) {
+			fields := parseFields(agent.ua)
+			cleaned := strings.Join(fields, " ")
+			expected, result := UserAgent{Fields: fields}, UserAgent{Fields: fields}
+			applyMatchesLinear(&expected, cleaned, matches)
+			applyMatches(&result, cleaned, rules)
+			if result.String() != expected.String() {
+				t.Errorf("expected/received:\n%s\n%s", expected.String(), result.String())
+			}
+		})
+	}
+}
+
+// BenchmarkMatchers compares the Aho-Corasick automaton with the original strings.Contains cascade,
+// using a table of 500 pattern matchers.
+func BenchmarkMatchers(b *testing.B) {
+	matches := benchmarkMatches(500)
+	rules := compileRules(matches)
+	for _, a := range benchmarkAgents {
+		fields := parseFields(a.ua)
+		cleaned := strings.Join(fields, " ")
+		b.Run("Contains-"+a.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ua := UserAgent{Fields: fields}
+				applyMatchesLinear(&ua, cleaned, matches)
+				blackhole = ua
+			}
+		})
+		b.Run("AhoCorasick-"+a.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ua := UserAgent{Fields: fields}
+				applyMatches(&ua, cleaned, rules)
+				blackhole = ua
+			}
+		})
+	}
+}
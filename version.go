@@ -0,0 +1,85 @@
+package user_agent
+
+import "strconv"
+
+// Version is a structured version number, which can be compared numerically. For example, Chrome/101.0.4951.67
+// has Major version 101, Minor version 0, Patch version 4951, and Build number 67. Numbers that aren't provided
+// (or can't be parsed) are zero, and the original text is retained in Raw.
+type Version struct {
+	Major int    `json:"major"`
+	Minor int    `json:"minor"`
+	Patch int    `json:"patch"`
+	Build int    `json:"build"`
+	Raw   string `json:"raw,omitempty"`
+}
+
+// ParseVersion parses the supplied text into a Version. Segments may be separated by periods or underscores (as in
+// Apple operating system versions, e.g. 15_4_1). Parsing stops at the first segment that doesn't begin with a
+// digit, and any non-numeric suffix is ignored (e.g. 14.0b12646 is version 14.0).
+func ParseVersion(text string) Version {
+	v := Version{Raw: text}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch, &v.Build}
+	i := 0
+	for _, n := range numbers {
+		start := i
+		for i < len(text) && isDigit(text[i]) {
+			i++
+		}
+		number, err := strconv.Atoi(text[start:i])
+		if err != nil {
+			break
+		}
+		*n = number
+		if i >= len(text) || (text[i] != '.' && text[i] != '_') {
+			break
+		}
+		i++
+	}
+	return v
+}
+
+// IsZero returns true if the Version was not provided.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Compare returns -1, 0, or +1 depending on whether the Version is less than, equal to, or greater than the
+// other Version, comparing the Major, Minor, Patch, and Build numbers in turn. The Raw text is ignored.
+func (v Version) Compare(other Version) int {
+	a := [4]int{v.Major, v.Minor, v.Patch, v.Build}
+	b := [4]int{other.Major, other.Minor, other.Patch, other.Build}
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		}
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast returns true if the Version is greater than or equal to the version indicated by the supplied numbers
+// (major, minor, patch, build), with missing numbers treated as zero. For example, AtLeast(100) checks for
+// version 100.0 or later.
+func (v Version) AtLeast(numbers ...int) bool {
+	var other Version
+	for i, n := range numbers {
+		switch i {
+		case 0:
+			other.Major = n
+		case 1:
+			other.Minor = n
+		case 2:
+			other.Patch = n
+		case 3:
+			other.Build = n
+		}
+	}
+	return v.Compare(other) >= 0
+}
+
+// String supports the Stringer interface, providing the original version text.
+func (v Version) String() string {
+	return v.Raw
+}
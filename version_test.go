@@ -0,0 +1,84 @@
+package user_agent
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Version
+	}{
+		{"", Version{}},
+		{"101", Version{Major: 101, Raw: "101"}},
+		{"101.0.4951.67", Version{Major: 101, Minor: 0, Patch: 4951, Build: 67, Raw: "101.0.4951.67"}},
+		{"15_4_1", Version{Major: 15, Minor: 4, Patch: 1, Raw: "15_4_1"}},
+		{"14.0b12646", Version{Major: 14, Minor: 0, Raw: "14.0b12646"}},
+		{"10.", Version{Major: 10, Raw: "10."}},
+		{"1.2.3.4.5", Version{Major: 1, Minor: 2, Patch: 3, Build: 4, Raw: "1.2.3.4.5"}},
+		{"W.X.Y.Z", Version{Raw: "W.X.Y.Z"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			v := ParseVersion(tt.text)
+			if v != tt.expected {
+				t.Errorf("expected %+v, received %+v", tt.expected, v)
+			}
+			if v.String() != tt.text {
+				t.Errorf("expected String %q, received %q", tt.text, v.String())
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"99.0", "100.0", -1},
+		{"100.0", "99.0", 1},
+		{"100", "100.0.0.0", 0},
+		{"15_4", "15.4", 0},
+		{"101.0.4951.67", "101.0.4951.64", 1},
+		{"1.10", "1.9", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if c := ParseVersion(tt.a).Compare(ParseVersion(tt.b)); c != tt.expected {
+				t.Errorf("expected %d, received %d", tt.expected, c)
+			}
+		})
+	}
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	v := ParseVersion("100.0.4896.75")
+	for _, n := range [][]int{{}, {99}, {100}, {100, 0, 4896}, {100, 0, 4896, 75}} {
+		if !v.AtLeast(n...) {
+			t.Errorf("expected %s to be at least %v", v, n)
+		}
+	}
+	for _, n := range [][]int{{101}, {100, 1}, {100, 0, 4896, 76}} {
+		if v.AtLeast(n...) {
+			t.Errorf("expected %s to be less than %v", v, n)
+		}
+	}
+	if !ParseVersion("").IsZero() || v.IsZero() {
+		t.Error("unexpected IsZero result")
+	}
+}
+
+func TestUserAgent_VersionNumbers(t *testing.T) {
+	ua := Parse("Mozilla/5.0 (iPhone; CPU iPhone OS 15_4_1 like Mac OS X) AppleWebKit/605.1.15 " +
+		"(KHTML, like Gecko) Version/15.4 Mobile/15E148 Safari/604.1")
+	if ua.ClientVersionNumber != ParseVersion("15.4") {
+		t.Errorf("unexpected client version %+v", ua.ClientVersionNumber)
+	}
+	if ua.OSVersionNumber != ParseVersion("15_4_1") {
+		t.Errorf("unexpected OS version %+v", ua.OSVersionNumber)
+	}
+	ua = Parse("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) " +
+		"Chrome/99.0.4844.84 Safari/537.36")
+	if !ua.ClientVersionNumber.AtLeast(99, 0, 4844) || ua.ClientVersionNumber.AtLeast(100) {
+		t.Errorf("unexpected client version %+v", ua.ClientVersionNumber)
+	}
+}